@@ -1,6 +1,6 @@
 package collect
 
-type List[T comparable] interface {
+type List[T any] interface {
 	Get(index int) T
 	SafeGet(index int) (T, bool)
 	IndexOf(element T) int
@@ -12,12 +12,12 @@ type ArrayList[T comparable] struct {
 }
 
 func NewList[T comparable](elements ...T) *ArrayList[T] {
-	return &ArrayList[T]{collectionWithSlice[T]{data: &elements}}
+	return &ArrayList[T]{newCollectionWithSlice(&elements)}
 }
 
 func NewListOf[T comparable](collection Collection[T]) *ArrayList[T] {
 	data := make([]T, collection.Size())
-	array := &ArrayList[T]{collectionWithSlice[T]{data: &data}}
+	array := &ArrayList[T]{newCollectionWithSlice(&data)}
 
 	i := 0
 	for el := range collection.Iterator() {
@@ -40,21 +40,19 @@ func (a *ArrayList[T]) SafeGet(index int) (T, bool) {
 	}
 }
 
-func (a *ArrayList[T]) IndexOf(element T) int {
-	i := 0
-	for el := range a.Iterator() {
-		if el == element {
-			return i
-		}
-		i++
-	}
-	return -1
-}
-
+// Slice returns the backing slice for direct writes, so it first copies data
+// that a snapshot still shares.
 func (a *ArrayList[T]) Slice() *[]T {
+	a.own()
 	return a.data
 }
 
+// Any returns a view of a that compares elements with ==. Changes made through
+// the view are visible in a and the other way round.
+func (a *ArrayList[T]) Any() *AnyList[T] {
+	return &AnyList[T]{a.sliceCollection}
+}
+
 func (a *ArrayList[T]) Equal(array *ArrayList[T]) bool {
 	if array.data == nil {
 		return false
@@ -70,3 +68,114 @@ func (a *ArrayList[T]) Equal(array *ArrayList[T]) bool {
 	}
 	return true
 }
+
+type AnyList[T any] struct {
+	sliceCollection[T]
+}
+
+// NewAnyList compares elements with reflect.DeepEqual; use NewAnyListFunc to
+// supply the equality.
+func NewAnyList[T any](elements ...T) *AnyList[T] {
+	return NewAnyListFunc(deepEqual[T], elements...)
+}
+
+func NewAnyListFunc[T any](equal func(a, b T) bool, elements ...T) *AnyList[T] {
+	return &AnyList[T]{newSliceCollection(&elements, equal)}
+}
+
+func NewAnyListOf[T any](collection Collection[T]) *AnyList[T] {
+	data := make([]T, 0, collection.Size())
+	for el := range collection.Iterator() {
+		data = append(data, el)
+	}
+	return NewAnyList(data...)
+}
+
+func (a *AnyList[T]) Get(index int) T {
+	return (*a.data)[index]
+}
+
+func (a *AnyList[T]) SafeGet(index int) (T, bool) {
+	if index >= 0 && len(*a.data) > index {
+		return (*a.data)[index], true
+	}
+	var t T
+	return t, false
+}
+
+func (a *AnyList[T]) Slice() *[]T {
+	a.own()
+	return a.data
+}
+
+func (a *AnyList[T]) EqualFunc(array *AnyList[T], equal func(a, b T) bool) bool {
+	if array == nil {
+		return false
+	}
+	if len(*a.data) != array.Size() {
+		return false
+	}
+
+	for idx, val := range *a.data {
+		if !equal(array.Get(idx), val) {
+			return false
+		}
+	}
+	return true
+}
+
+type EquivalenceList[T any] struct {
+	sliceCollection[T]
+	eq Equivalence[T]
+}
+
+func NewListWith[T any](eq Equivalence[T], elements ...T) *EquivalenceList[T] {
+	return &EquivalenceList[T]{
+		sliceCollection: newSliceCollection(&elements, eq.Equal),
+		eq:              eq,
+	}
+}
+
+func NewListWithOf[T any](eq Equivalence[T], collection Collection[T]) *EquivalenceList[T] {
+	data := make([]T, 0, collection.Size())
+	for el := range collection.Iterator() {
+		data = append(data, el)
+	}
+	return NewListWith(eq, data...)
+}
+
+func (a *EquivalenceList[T]) Get(index int) T {
+	return (*a.data)[index]
+}
+
+func (a *EquivalenceList[T]) SafeGet(index int) (T, bool) {
+	if index >= 0 && len(*a.data) > index {
+		return (*a.data)[index], true
+	}
+	var t T
+	return t, false
+}
+
+func (a *EquivalenceList[T]) Slice() *[]T {
+	return a.data
+}
+
+func (a *EquivalenceList[T]) Equivalence() Equivalence[T] {
+	return a.eq
+}
+
+func (a *EquivalenceList[T]) Equal(array *EquivalenceList[T]) bool {
+	if array == nil {
+		return false
+	}
+	if len(*a.data) != array.Size() {
+		return false
+	}
+
+	for idx, val := range *a.data {
+		if !a.eq.Equal(array.Get(idx), val) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,7 +1,68 @@
 package collect
 
-import "testing"
+import (
+	"bytes"
+	"testing"
+)
 
 func TestArrayList_Get(t *testing.T) {
 
 }
+
+func TestAnyList_EqualityFuncs(t *testing.T) {
+	equal := func(a, b []byte) bool { return string(a) == string(b) }
+	list := NewAnyList([]byte("a"), []byte("b"), []byte("c"))
+
+	if list.IndexOfFunc([]byte("c"), equal) != 2 {
+		t.Errorf("expected error, index=%d, got=%d", 2, list.IndexOfFunc([]byte("c"), equal))
+	}
+	if !list.RemoveFunc([]byte("b"), equal) || list.ContainsFunc([]byte("b"), equal) {
+		t.Errorf("expected error, not deleted %s", "b")
+	}
+	if list.Size() != 2 {
+		t.Errorf("expected error, size=%d, got=%d", 2, list.Size())
+	}
+}
+
+func TestArrayList_Any(t *testing.T) {
+	list := NewList(1, 2, 3)
+	list.Any().Add(4)
+	if list.Size() != 4 || list.Get(3) != 4 {
+		t.Errorf("expected error, view not shared %s", list)
+	}
+
+	queue := NewQueue(1, 2)
+	if queue.Any().Pool() != 1 || queue.Peek() != 2 {
+		t.Errorf("expected error, view not shared %v", queue)
+	}
+}
+
+func TestAnyList_Collection(t *testing.T) {
+	var list List[[]byte] = NewAnyList([]byte("a"), []byte("b"))
+	if list.IndexOf([]byte("b")) != 1 {
+		t.Errorf("expected error, index=%d, got=%d", 1, list.IndexOf([]byte("b")))
+	}
+
+	var coll Collection[[]byte] = NewAnyListFunc(bytes.Equal, []byte("a"), []byte("b"))
+	if !coll.Contains([]byte("a")) || !coll.Remove([]byte("a")) || coll.Contains([]byte("a")) {
+		t.Errorf("expected error, incorrect equality %s", coll)
+	}
+}
+
+func TestArrayList_AnyModification(t *testing.T) {
+	list := NewList(1, 2, 3)
+	expectConcurrentModification(t, func() {
+		list.ForEach(func(int) {
+			list.Any().Add(4)
+		})
+	})
+
+	iter := list.Iter()
+	iter.Next()
+	list.Any().RemoveIf(func(el int) bool {
+		return el == 4
+	})
+	expectConcurrentModification(t, func() {
+		iter.Next()
+	})
+}
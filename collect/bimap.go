@@ -0,0 +1,276 @@
+package collect
+
+import (
+	"fmt"
+	"strings"
+)
+
+type BiMap[K comparable, V comparable] interface {
+	Put(key K, value V) bool
+	ForcePut(key K, value V)
+	Get(key K) (V, bool)
+	Remove(key K) (V, bool)
+
+	ContainsKey(key K) bool
+	ContainsValue(value V) bool
+
+	Inverse() BiMap[V, K]
+	KeySet() Set[K]
+	Values() Set[V]
+
+	Size() int
+	IsEmpty() bool
+	Clear()
+	ForEach(do func(K, V))
+	String() string
+}
+
+type HashBiMap[K comparable, V comparable] struct {
+	forward  map[K]V
+	backward map[V]K
+	inverse  *HashBiMap[V, K]
+	mods     *int
+}
+
+func NewBiMap[K comparable, V comparable]() *HashBiMap[K, V] {
+	m := &HashBiMap[K, V]{forward: make(map[K]V), backward: make(map[V]K), mods: new(int)}
+	m.inverse = &HashBiMap[V, K]{forward: m.backward, backward: m.forward, inverse: m, mods: m.mods}
+	return m
+}
+
+func NewBiMapOf[K comparable, V comparable](data map[K]V) *HashBiMap[K, V] {
+	m := NewBiMap[K, V]()
+	for key, value := range data {
+		if !m.Put(key, value) {
+			panic(fmt.Sprintf("collect: value %v already bound to key %v", value, m.backward[value]))
+		}
+	}
+	return m
+}
+
+func (m *HashBiMap[K, V]) Put(key K, value V) bool {
+	if bound, ok := m.backward[value]; ok {
+		return bound == key
+	}
+	if old, ok := m.forward[key]; ok {
+		delete(m.backward, old)
+	}
+	m.forward[key] = value
+	m.backward[value] = key
+	*m.mods++
+	return true
+}
+
+func (m *HashBiMap[K, V]) ForcePut(key K, value V) {
+	if bound, ok := m.backward[value]; ok && bound == key {
+		return
+	}
+	if bound, ok := m.backward[value]; ok {
+		delete(m.forward, bound)
+	}
+	if old, ok := m.forward[key]; ok {
+		delete(m.backward, old)
+	}
+	m.forward[key] = value
+	m.backward[value] = key
+	*m.mods++
+}
+
+func (m *HashBiMap[K, V]) Get(key K) (V, bool) {
+	value, ok := m.forward[key]
+	return value, ok
+}
+
+func (m *HashBiMap[K, V]) Remove(key K) (V, bool) {
+	value, ok := m.forward[key]
+	if ok {
+		delete(m.forward, key)
+		delete(m.backward, value)
+		*m.mods++
+	}
+	return value, ok
+}
+
+func (m *HashBiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.forward[key]
+	return ok
+}
+
+func (m *HashBiMap[K, V]) ContainsValue(value V) bool {
+	_, ok := m.backward[value]
+	return ok
+}
+
+func (m *HashBiMap[K, V]) Inverse() BiMap[V, K] {
+	return m.inverse
+}
+
+func (m *HashBiMap[K, V]) KeySet() Set[K] {
+	return &biMapKeySet[K, V]{m: m}
+}
+
+func (m *HashBiMap[K, V]) Values() Set[V] {
+	return m.inverse.KeySet()
+}
+
+func (m *HashBiMap[K, V]) Size() int {
+	return len(m.forward)
+}
+
+func (m *HashBiMap[K, V]) IsEmpty() bool {
+	return len(m.forward) == 0
+}
+
+func (m *HashBiMap[K, V]) Clear() {
+	for key := range m.forward {
+		delete(m.forward, key)
+	}
+	for value := range m.backward {
+		delete(m.backward, value)
+	}
+	*m.mods++
+}
+
+func (m *HashBiMap[K, V]) ForEach(do func(K, V)) {
+	mods := *m.mods
+	for key, value := range m.forward {
+		do(key, value)
+		checkModifications(mods, *m.mods)
+	}
+}
+
+func (m *HashBiMap[K, V]) String() string {
+	var data []string
+	for key, value := range m.forward {
+		data = append(data, fmt.Sprintf("%v:%v", key, value))
+	}
+	return "{" + strings.Join(data, " ") + "}"
+}
+
+type biMapKeySet[K comparable, V comparable] struct {
+	m *HashBiMap[K, V]
+}
+
+func (s *biMapKeySet[K, V]) Equal(elements Set[K]) bool {
+	if elements == nil {
+		return false
+	}
+	if len(s.m.forward) != elements.Size() {
+		return false
+	}
+
+	for key := range s.m.forward {
+		if !elements.Contains(key) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *biMapKeySet[K, V]) Add(K) {
+	panic("collect: cannot add to a BiMap key or value view")
+}
+
+func (s *biMapKeySet[K, V]) AddAll(Collection[K]) {
+	panic("collect: cannot add to a BiMap key or value view")
+}
+
+func (s *biMapKeySet[K, V]) AddAllSlice([]K) {
+	panic("collect: cannot add to a BiMap key or value view")
+}
+
+func (s *biMapKeySet[K, V]) Contains(element K) bool {
+	return s.m.ContainsKey(element)
+}
+
+func (s *biMapKeySet[K, V]) ContainsAll(elements Collection[K]) bool {
+	for el := range elements.Iterator() {
+		if !s.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *biMapKeySet[K, V]) ContainsAllSlice(elements []K) bool {
+	for _, el := range elements {
+		if !s.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *biMapKeySet[K, V]) Remove(element K) bool {
+	_, ok := s.m.Remove(element)
+	return ok
+}
+
+func (s *biMapKeySet[K, V]) RemoveAll(elements Collection[K]) bool {
+	modified := false
+	for el := range elements.Iterator() {
+		if s.Remove(el) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (s *biMapKeySet[K, V]) RemoveAllSlice(elements []K) bool {
+	modified := false
+	for _, el := range elements {
+		if s.Remove(el) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (s *biMapKeySet[K, V]) RemoveIf(predicate func(K) bool) bool {
+	modified := false
+	for key := range s.m.forward {
+		if predicate(key) && s.Remove(key) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (s *biMapKeySet[K, V]) Size() int {
+	return s.m.Size()
+}
+
+func (s *biMapKeySet[K, V]) IsEmpty() bool {
+	return s.m.IsEmpty()
+}
+
+func (s *biMapKeySet[K, V]) Clear() {
+	s.m.Clear()
+}
+
+func (s *biMapKeySet[K, V]) Iterator() <-chan K {
+	pool := make(chan K, len(s.m.forward))
+	defer close(pool)
+
+	for key := range s.m.forward {
+		pool <- key
+	}
+
+	return pool
+}
+
+func (s *biMapKeySet[K, V]) ForEach(do func(K)) {
+	mods := *s.m.mods
+	for key := range s.m.forward {
+		do(key)
+		checkModifications(mods, *s.m.mods)
+	}
+}
+
+func (s *biMapKeySet[K, V]) String() string {
+	var data []string
+	for key := range s.m.forward {
+		data = append(data, fmt.Sprint(key))
+	}
+	return "[" + strings.Join(data, " ") + "]"
+}
@@ -0,0 +1,52 @@
+package collect
+
+import "testing"
+
+func TestHashBiMap_Put(t *testing.T) {
+	m := NewBiMap[int, string]()
+	if !m.Put(1, "one") || !m.Put(2, "two") {
+		t.Errorf("expected error, not stored %s", m)
+	}
+	if m.Put(3, "one") {
+		t.Errorf("expected error, duplicate value stored %s", m)
+	}
+	if !m.Put(1, "uno") || m.ContainsValue("one") {
+		t.Errorf("expected error, old value not replaced %s", m)
+	}
+
+	m.ForcePut(3, "two")
+	if m.ContainsKey(2) || m.Size() != 2 {
+		t.Errorf("expected error, conflicting entry not evicted %s", m)
+	}
+}
+
+func TestHashBiMap_Inverse(t *testing.T) {
+	m := NewBiMapOf(map[int]string{1: "one", 2: "two"})
+	inverse := m.Inverse()
+	if key, ok := inverse.Get("two"); !ok || key != 2 {
+		t.Errorf("expected error, key=%d, got=%d", 2, key)
+	}
+
+	inverse.Put("three", 3)
+	if value, ok := m.Get(3); !ok || value != "three" {
+		t.Errorf("expected error, inverse not live %s", m)
+	}
+	if inverse.Inverse() != BiMap[int, string](m) {
+		t.Errorf("expected error, double inverse differs")
+	}
+}
+
+func TestHashBiMap_Views(t *testing.T) {
+	m := NewBiMapOf(map[int]string{1: "one", 2: "two", 3: "three"})
+	if !m.KeySet().Equal(NewSet(1, 2, 3)) || !m.Values().Equal(NewSet("one", "two", "three")) {
+		t.Errorf("expected error, incorrect views %s", m)
+	}
+
+	m.Values().Remove("two")
+	m.KeySet().RemoveIf(func(key int) bool {
+		return key == 3
+	})
+	if m.Size() != 1 || !m.ContainsKey(1) {
+		t.Errorf("expected error, views not live %s", m)
+	}
+}
@@ -0,0 +1,275 @@
+package bitset
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+type BitSet struct {
+	words []uint64
+}
+
+func New(size int) *BitSet {
+	if size < 0 {
+		panic(fmt.Sprintf("bitset: negative size %d", size))
+	}
+	return &BitSet{words: make([]uint64, (size+63)/64)}
+}
+
+func Of(indexes ...int) *BitSet {
+	b := New(0)
+	for _, i := range indexes {
+		b.Set(i)
+	}
+	return b
+}
+
+func FromCollection(elements collect.Collection[int]) *BitSet {
+	b := New(0)
+	for i := range elements.Iterator() {
+		b.Set(i)
+	}
+	return b
+}
+
+func (b *BitSet) ToSet() *collect.HashSet[int] {
+	set := collect.NewSet[int]()
+	b.ForEach(func(i int) {
+		set.Add(i)
+	})
+	return set
+}
+
+func (b *BitSet) Set(i int) {
+	checkIndex(i)
+	b.grow(i/64 + 1)
+	b.words[i/64] |= 1 << (i % 64)
+}
+
+func (b *BitSet) Clear(i int) {
+	checkIndex(i)
+	if i/64 < len(b.words) {
+		b.words[i/64] &^= 1 << (i % 64)
+	}
+}
+
+func (b *BitSet) Flip(i int) {
+	checkIndex(i)
+	b.grow(i/64 + 1)
+	b.words[i/64] ^= 1 << (i % 64)
+}
+
+func (b *BitSet) Test(i int) bool {
+	checkIndex(i)
+	return i/64 < len(b.words) && b.words[i/64]&(1<<(i%64)) != 0
+}
+
+func (b *BitSet) SetRange(from, to int) {
+	checkRange(from, to)
+	if from == to {
+		return
+	}
+	b.grow((to-1)/64 + 1)
+	b.applyRange(from, to, func(word, mask uint64) uint64 { return word | mask })
+}
+
+func (b *BitSet) ClearRange(from, to int) {
+	checkRange(from, to)
+	if from == to || from/64 >= len(b.words) {
+		return
+	}
+	if limit := len(b.words) * 64; to > limit {
+		to = limit
+	}
+	b.applyRange(from, to, func(word, mask uint64) uint64 { return word &^ mask })
+}
+
+func (b *BitSet) FlipRange(from, to int) {
+	checkRange(from, to)
+	if from == to {
+		return
+	}
+	b.grow((to-1)/64 + 1)
+	b.applyRange(from, to, func(word, mask uint64) uint64 { return word ^ mask })
+}
+
+func (b *BitSet) And(other *BitSet) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+}
+
+func (b *BitSet) Or(other *BitSet) {
+	b.grow(len(other.words))
+	for i, word := range other.words {
+		b.words[i] |= word
+	}
+}
+
+func (b *BitSet) Xor(other *BitSet) {
+	b.grow(len(other.words))
+	for i, word := range other.words {
+		b.words[i] ^= word
+	}
+}
+
+func (b *BitSet) AndNot(other *BitSet) {
+	for i := 0; i < len(b.words) && i < len(other.words); i++ {
+		b.words[i] &^= other.words[i]
+	}
+}
+
+func (b *BitSet) Cardinality() int {
+	count := 0
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// Len returns the index of the highest set bit plus one.
+func (b *BitSet) Len() int {
+	for i := len(b.words) - 1; i >= 0; i-- {
+		if b.words[i] != 0 {
+			return i*64 + 64 - bits.LeadingZeros64(b.words[i])
+		}
+	}
+	return 0
+}
+
+func (b *BitSet) IsEmpty() bool {
+	return b.Len() == 0
+}
+
+func (b *BitSet) NextSetBit(from int) int {
+	checkIndex(from)
+	i := from / 64
+	if i >= len(b.words) {
+		return -1
+	}
+	word := b.words[i] >> (from % 64)
+	if word != 0 {
+		return from + bits.TrailingZeros64(word)
+	}
+	for i++; i < len(b.words); i++ {
+		if b.words[i] != 0 {
+			return i*64 + bits.TrailingZeros64(b.words[i])
+		}
+	}
+	return -1
+}
+
+func (b *BitSet) NextClearBit(from int) int {
+	checkIndex(from)
+	i := from / 64
+	if i >= len(b.words) {
+		return from
+	}
+	word := ^b.words[i] >> (from % 64)
+	if word != 0 {
+		return from + bits.TrailingZeros64(word)
+	}
+	for i++; i < len(b.words); i++ {
+		if b.words[i] != ^uint64(0) {
+			return i*64 + bits.TrailingZeros64(^b.words[i])
+		}
+	}
+	return len(b.words) * 64
+}
+
+func (b *BitSet) ClearAll() {
+	for i := range b.words {
+		b.words[i] = 0
+	}
+}
+
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+func (b *BitSet) Equal(other *BitSet) bool {
+	if other == nil {
+		return false
+	}
+	longer, shorter := b.words, other.words
+	if len(longer) < len(shorter) {
+		longer, shorter = shorter, longer
+	}
+	for i, word := range longer {
+		if i < len(shorter) {
+			if word != shorter[i] {
+				return false
+			}
+		} else if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BitSet) Iterator() <-chan int {
+	pool := make(chan int, b.Cardinality())
+	defer close(pool)
+
+	b.ForEach(func(i int) {
+		pool <- i
+	})
+
+	return pool
+}
+
+func (b *BitSet) ForEach(do func(int)) {
+	for i, word := range b.words {
+		for word != 0 {
+			do(i*64 + bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+}
+
+func (b *BitSet) String() string {
+	var data []string
+	b.ForEach(func(i int) {
+		data = append(data, fmt.Sprint(i))
+	})
+	return "[" + strings.Join(data, " ") + "]"
+}
+
+func (b *BitSet) grow(words int) {
+	if words > len(b.words) {
+		b.words = append(b.words, make([]uint64, words-len(b.words))...)
+	}
+}
+
+func (b *BitSet) applyRange(from, to int, op func(word, mask uint64) uint64) {
+	first, last := from/64, (to-1)/64
+	for i := first; i <= last; i++ {
+		mask := ^uint64(0)
+		if i == first {
+			mask &= ^uint64(0) << (from % 64)
+		}
+		if i == last {
+			mask &= ^uint64(0) >> (63 - (to-1)%64)
+		}
+		b.words[i] = op(b.words[i], mask)
+	}
+}
+
+func checkIndex(i int) {
+	if i < 0 {
+		panic(fmt.Sprintf("bitset: negative index %d", i))
+	}
+}
+
+func checkRange(from, to int) {
+	if from < 0 || to < from {
+		panic(fmt.Sprintf("bitset: invalid range [%d, %d)", from, to))
+	}
+}
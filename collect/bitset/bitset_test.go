@@ -0,0 +1,82 @@
+package bitset
+
+import (
+	"testing"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func TestBitSet_Bits(t *testing.T) {
+	b := New(10)
+	b.Set(3)
+	b.Set(200)
+	b.Flip(4)
+	b.Flip(3)
+	if b.Test(3) || !b.Test(4) || !b.Test(200) || b.Test(1_000) {
+		t.Errorf("expected error, incorrect bits %s", b)
+	}
+	if b.Cardinality() != 2 || b.Len() != 201 {
+		t.Errorf("expected error, cardinality=%d, got=%d", 2, b.Cardinality())
+	}
+	if b.String() != "[4 200]" {
+		t.Errorf("expected error, string=%s, got=%s", "[4 200]", b)
+	}
+	b.Clear(200)
+	b.Clear(5_000)
+	if b.Len() != 5 {
+		t.Errorf("expected error, len=%d, got=%d", 5, b.Len())
+	}
+}
+
+func TestBitSet_Ranges(t *testing.T) {
+	b := New(0)
+	b.SetRange(60, 130)
+	if b.Cardinality() != 70 || !b.Test(60) || !b.Test(129) || b.Test(130) {
+		t.Errorf("expected error, incorrect SetRange %s", b)
+	}
+	b.ClearRange(64, 128)
+	if b.Cardinality() != 6 || b.NextSetBit(61) != 61 || b.NextSetBit(64) != 128 {
+		t.Errorf("expected error, incorrect ClearRange %s", b)
+	}
+	b.FlipRange(0, 64)
+	if b.NextClearBit(0) != 60 || b.NextClearBit(64) != 64 || b.NextSetBit(130) != -1 {
+		t.Errorf("expected error, incorrect FlipRange %s", b)
+	}
+}
+
+func TestBitSet_Operations(t *testing.T) {
+	tests := []struct {
+		name   string
+		op     func(a, b *BitSet)
+		result *BitSet
+	}{
+		{name: "and", op: (*BitSet).And, result: Of(2, 100)},
+		{name: "or", op: (*BitSet).Or, result: Of(1, 2, 3, 100, 300)},
+		{name: "xor", op: (*BitSet).Xor, result: Of(1, 3, 300)},
+		{name: "andNot", op: (*BitSet).AndNot, result: Of(1)},
+	}
+
+	for _, test := range tests {
+		a := Of(1, 2, 100)
+		test.op(a, Of(2, 3, 100, 300))
+		if !a.Equal(test.result) {
+			t.Errorf("expected error, %s=%s, got=%s", test.name, test.result, a)
+		}
+	}
+}
+
+func TestBitSet_Set(t *testing.T) {
+	set := collect.NewSet(5, 64, 1_000)
+	b := FromCollection(set)
+	if !b.ToSet().Equal(set) {
+		t.Errorf("expected error, set=%s, got=%s", set, b.ToSet())
+	}
+
+	var visited []int
+	for i := range b.Iterator() {
+		visited = append(visited, i)
+	}
+	if len(visited) != 3 || visited[0] != 5 || visited[2] != 1_000 {
+		t.Errorf("expected error, incorrect iteration %v", visited)
+	}
+}
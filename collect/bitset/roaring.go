@@ -0,0 +1,456 @@
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+const (
+	serialCookieNoRuns = 12346
+	serialCookie       = 12347
+	noOffsetThreshold  = 4
+	arrayMaxSize       = 4096
+	bitmapWords        = 1024
+)
+
+var ErrInvalidData = errors.New("bitset: invalid roaring data")
+
+// Bitmap is a compressed set of uint32 values. Values are split by their high
+// 16 bits into containers holding either a sorted array of the low 16 bits or,
+// once a container has more than 4096 values, a 65536-bit bitmap.
+type Bitmap struct {
+	keys       []uint16
+	containers []*container
+}
+
+type container struct {
+	array  []uint16
+	bitmap []uint64
+	card   int
+}
+
+func NewBitmap(values ...uint32) *Bitmap {
+	b := &Bitmap{}
+	for _, v := range values {
+		b.Add(v)
+	}
+	return b
+}
+
+func (b *Bitmap) Add(value uint32) bool {
+	key, low := uint16(value>>16), uint16(value)
+	idx, ok := b.find(key)
+	if !ok {
+		b.keys = append(b.keys, 0)
+		copy(b.keys[idx+1:], b.keys[idx:])
+		b.keys[idx] = key
+		b.containers = append(b.containers, nil)
+		copy(b.containers[idx+1:], b.containers[idx:])
+		b.containers[idx] = &container{}
+	}
+	return b.containers[idx].add(low)
+}
+
+func (b *Bitmap) Remove(value uint32) bool {
+	key, low := uint16(value>>16), uint16(value)
+	idx, ok := b.find(key)
+	if !ok || !b.containers[idx].remove(low) {
+		return false
+	}
+	if b.containers[idx].card == 0 {
+		b.keys = append(b.keys[:idx], b.keys[idx+1:]...)
+		b.containers = append(b.containers[:idx], b.containers[idx+1:]...)
+	}
+	return true
+}
+
+func (b *Bitmap) Contains(value uint32) bool {
+	idx, ok := b.find(uint16(value >> 16))
+	return ok && b.containers[idx].contains(uint16(value))
+}
+
+func (b *Bitmap) Cardinality() uint64 {
+	var card uint64
+	for _, c := range b.containers {
+		card += uint64(c.card)
+	}
+	return card
+}
+
+func (b *Bitmap) IsEmpty() bool {
+	return len(b.keys) == 0
+}
+
+func (b *Bitmap) Or(other *Bitmap) *Bitmap {
+	result := &Bitmap{}
+	i, j := 0, 0
+	for i < len(b.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(b.keys) && b.keys[i] < other.keys[j]):
+			result.push(b.keys[i], b.containers[i].clone())
+			i++
+		case i == len(b.keys) || other.keys[j] < b.keys[i]:
+			result.push(other.keys[j], other.containers[j].clone())
+			j++
+		default:
+			words := b.containers[i].words()
+			for k, word := range other.containers[j].words() {
+				words[k] |= word
+			}
+			result.push(b.keys[i], containerOf(words))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+func (b *Bitmap) And(other *Bitmap) *Bitmap {
+	result := &Bitmap{}
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(other.keys) {
+		switch {
+		case b.keys[i] < other.keys[j]:
+			i++
+		case other.keys[j] < b.keys[i]:
+			j++
+		default:
+			words := b.containers[i].words()
+			for k, word := range other.containers[j].words() {
+				words[k] &= word
+			}
+			if c := containerOf(words); c.card > 0 {
+				result.push(b.keys[i], c)
+			}
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	if other == nil || len(b.keys) != len(other.keys) {
+		return false
+	}
+	for i, key := range b.keys {
+		if key != other.keys[i] || b.containers[i].card != other.containers[i].card {
+			return false
+		}
+		words := other.containers[i].words()
+		for k, word := range b.containers[i].words() {
+			if word != words[k] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (b *Bitmap) ToSlice() []uint32 {
+	result := make([]uint32, 0, b.Cardinality())
+	b.ForEach(func(value uint32) {
+		result = append(result, value)
+	})
+	return result
+}
+
+func (b *Bitmap) Iterator() <-chan uint32 {
+	pool := make(chan uint32, b.Cardinality())
+	defer close(pool)
+
+	b.ForEach(func(value uint32) {
+		pool <- value
+	})
+
+	return pool
+}
+
+func (b *Bitmap) ForEach(do func(uint32)) {
+	for i, c := range b.containers {
+		high := uint32(b.keys[i]) << 16
+		if c.bitmap == nil {
+			for _, low := range c.array {
+				do(high | uint32(low))
+			}
+			continue
+		}
+		for k, word := range c.bitmap {
+			for word != 0 {
+				do(high | uint32(k*64+bits.TrailingZeros64(word)))
+				word &= word - 1
+			}
+		}
+	}
+}
+
+func (b *Bitmap) String() string {
+	var data []string
+	b.ForEach(func(value uint32) {
+		data = append(data, fmt.Sprint(value))
+	})
+	return "[" + strings.Join(data, " ") + "]"
+}
+
+// MarshalBinary writes the portable Roaring format without run containers.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	size := len(b.keys)
+	data := make([]byte, 0, 8+8*size)
+	data = binary.LittleEndian.AppendUint32(data, serialCookieNoRuns)
+	data = binary.LittleEndian.AppendUint32(data, uint32(size))
+	for i, key := range b.keys {
+		data = binary.LittleEndian.AppendUint16(data, key)
+		data = binary.LittleEndian.AppendUint16(data, uint16(b.containers[i].card-1))
+	}
+
+	offset := uint32(len(data) + 4*size)
+	for _, c := range b.containers {
+		data = binary.LittleEndian.AppendUint32(data, offset)
+		if c.bitmap != nil {
+			offset += 8 * bitmapWords
+		} else {
+			offset += uint32(2 * c.card)
+		}
+	}
+
+	for _, c := range b.containers {
+		if c.bitmap != nil {
+			for _, word := range c.bitmap {
+				data = binary.LittleEndian.AppendUint64(data, word)
+			}
+		} else {
+			for _, low := range c.array {
+				data = binary.LittleEndian.AppendUint16(data, low)
+			}
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary reads the portable Roaring format, with or without run
+// containers. Run containers are converted to array or bitmap containers.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	r := &reader{data: data}
+	cookie := r.uint32()
+	var size int
+	var runs []byte
+	switch {
+	case cookie == serialCookieNoRuns:
+		size = int(r.uint32())
+	case cookie&0xFFFF == serialCookie:
+		size = int(cookie>>16) + 1
+		runs = r.bytes((size + 7) / 8)
+	default:
+		return ErrInvalidData
+	}
+	if r.err != nil || size > 1<<16 {
+		return ErrInvalidData
+	}
+
+	keys := make([]uint16, size)
+	cards := make([]int, size)
+	for i := range keys {
+		keys[i] = r.uint16()
+		cards[i] = int(r.uint16()) + 1
+		if i > 0 && keys[i] <= keys[i-1] {
+			return ErrInvalidData
+		}
+	}
+	if runs == nil || size >= noOffsetThreshold {
+		r.bytes(4 * size)
+	}
+
+	containers := make([]*container, size)
+	for i := range containers {
+		switch {
+		case runs != nil && runs[i/8]&(1<<(i%8)) != 0:
+			words := make([]uint64, bitmapWords)
+			for n := int(r.uint16()); n > 0 && r.err == nil; n-- {
+				start, length := int(r.uint16()), int(r.uint16())
+				if start+length > 0xFFFF {
+					return ErrInvalidData
+				}
+				for v := start; v <= start+length; v++ {
+					words[v/64] |= 1 << (v % 64)
+				}
+			}
+			containers[i] = containerOf(words)
+		case cards[i] > arrayMaxSize:
+			words := make([]uint64, bitmapWords)
+			for k := range words {
+				words[k] = r.uint64()
+			}
+			containers[i] = containerOf(words)
+		default:
+			array := make([]uint16, cards[i])
+			for k := range array {
+				array[k] = r.uint16()
+				if k > 0 && array[k] <= array[k-1] {
+					return ErrInvalidData
+				}
+			}
+			containers[i] = &container{array: array, card: len(array)}
+		}
+		if r.err != nil || containers[i].card != cards[i] {
+			return ErrInvalidData
+		}
+	}
+
+	b.keys, b.containers = keys, containers
+	return nil
+}
+
+func (b *Bitmap) find(key uint16) (int, bool) {
+	idx := sort.Search(len(b.keys), func(i int) bool {
+		return b.keys[i] >= key
+	})
+	return idx, idx < len(b.keys) && b.keys[idx] == key
+}
+
+func (b *Bitmap) push(key uint16, c *container) {
+	b.keys = append(b.keys, key)
+	b.containers = append(b.containers, c)
+}
+
+func (c *container) add(low uint16) bool {
+	if c.bitmap != nil {
+		if c.bitmap[low/64]&(1<<(low%64)) != 0 {
+			return false
+		}
+		c.bitmap[low/64] |= 1 << (low % 64)
+		c.card++
+		return true
+	}
+
+	idx, ok := c.search(low)
+	if ok {
+		return false
+	}
+	if c.card == arrayMaxSize {
+		c.bitmap = c.words()
+		c.array = nil
+		c.bitmap[low/64] |= 1 << (low % 64)
+		c.card++
+		return true
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[idx+1:], c.array[idx:])
+	c.array[idx] = low
+	c.card++
+	return true
+}
+
+func (c *container) remove(low uint16) bool {
+	if c.bitmap != nil {
+		if c.bitmap[low/64]&(1<<(low%64)) == 0 {
+			return false
+		}
+		c.bitmap[low/64] &^= 1 << (low % 64)
+		c.card--
+		if c.card <= arrayMaxSize {
+			*c = *containerOf(c.bitmap)
+		}
+		return true
+	}
+
+	idx, ok := c.search(low)
+	if !ok {
+		return false
+	}
+	c.array = append(c.array[:idx], c.array[idx+1:]...)
+	c.card--
+	return true
+}
+
+func (c *container) contains(low uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[low/64]&(1<<(low%64)) != 0
+	}
+	_, ok := c.search(low)
+	return ok
+}
+
+func (c *container) search(low uint16) (int, bool) {
+	idx := sort.Search(len(c.array), func(i int) bool {
+		return c.array[i] >= low
+	})
+	return idx, idx < len(c.array) && c.array[idx] == low
+}
+
+func (c *container) words() []uint64 {
+	if c.bitmap != nil {
+		return append([]uint64(nil), c.bitmap...)
+	}
+	words := make([]uint64, bitmapWords)
+	for _, low := range c.array {
+		words[low/64] |= 1 << (low % 64)
+	}
+	return words
+}
+
+func (c *container) clone() *container {
+	return &container{
+		array:  append([]uint16(nil), c.array...),
+		bitmap: append([]uint64(nil), c.bitmap...),
+		card:   c.card,
+	}
+}
+
+func containerOf(words []uint64) *container {
+	card := 0
+	for _, word := range words {
+		card += bits.OnesCount64(word)
+	}
+	if card > arrayMaxSize {
+		return &container{bitmap: words, card: card}
+	}
+
+	array := make([]uint16, 0, card)
+	for k, word := range words {
+		for word != 0 {
+			array = append(array, uint16(k*64+bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+	return &container{array: array, card: card}
+}
+
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil || n > len(r.data) {
+		r.err = ErrInvalidData
+		return nil
+	}
+	result := r.data[:n]
+	r.data = r.data[n:]
+	return result
+}
+
+func (r *reader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *reader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *reader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package bitset
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestBitmap_Containers(t *testing.T) {
+	b := NewBitmap()
+	for i := uint32(0); i < 10_000; i++ {
+		b.Add(i * 2)
+	}
+	b.Add(1 << 31)
+	if b.Cardinality() != 10_001 || !b.Contains(19_998) || b.Contains(19_999) || !b.Contains(1<<31) {
+		t.Errorf("expected error, incorrect bitmap cardinality=%d", b.Cardinality())
+	}
+	if b.containers[0].bitmap == nil {
+		t.Errorf("expected error, dense container not converted to bitmap")
+	}
+
+	for i := uint32(0); i < 10_000; i++ {
+		b.Remove(i * 2)
+	}
+	if b.Cardinality() != 1 || len(b.keys) != 1 {
+		t.Errorf("expected error, empty containers kept %s", b)
+	}
+	if b.String() != "[2147483648]" {
+		t.Errorf("expected error, string=%s, got=%s", "[2147483648]", b)
+	}
+}
+
+func TestBitmap_Operations(t *testing.T) {
+	a := NewBitmap(1, 2, 70_000, 1<<20)
+	b := NewBitmap(2, 3, 1<<20)
+
+	if or := a.Or(b); !or.Equal(NewBitmap(1, 2, 3, 70_000, 1<<20)) {
+		t.Errorf("expected error, incorrect union %s", or)
+	}
+	if and := a.And(b); !and.Equal(NewBitmap(2, 1<<20)) {
+		t.Errorf("expected error, incorrect intersection %s", and)
+	}
+}
+
+func TestBitmap_SpecFormat(t *testing.T) {
+	b := NewBitmap(1, 2, 65_541)
+	data, _ := b.MarshalBinary()
+	expected := []byte{
+		0x3A, 0x30, 0, 0, 2, 0, 0, 0,
+		0, 0, 1, 0, 1, 0, 0, 0,
+		24, 0, 0, 0, 28, 0, 0, 0,
+		1, 0, 2, 0, 5, 0,
+	}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("expected error, bytes=%v, got=%v", expected, data)
+	}
+
+	runs := []byte{
+		0x3B, 0x30, 0, 0, 0x01,
+		0, 0, 9, 0,
+		1, 0, 10, 0, 9, 0,
+	}
+	restored := NewBitmap()
+	if err := restored.UnmarshalBinary(runs); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Cardinality() != 10 || !restored.Contains(10) || !restored.Contains(19) || restored.Contains(20) {
+		t.Errorf("expected error, incorrect run container %s", restored)
+	}
+
+	if err := restored.UnmarshalBinary(runs[:7]); err != ErrInvalidData {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
+
+func TestBitmap_RoundTrip(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	b := NewBitmap()
+	for i := 0; i < 20_000; i++ {
+		b.Add(uint32(random.Intn(1 << 18)))
+	}
+	data, _ := b.MarshalBinary()
+	restored := NewBitmap()
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !restored.Equal(b) {
+		t.Errorf("expected error, round trip changed bitmap")
+	}
+}
@@ -0,0 +1,261 @@
+package blocking
+
+import (
+	"github.com/ukrainskiys/go-collections/collect"
+	"sync"
+	"sync/atomic"
+)
+
+// HashBiMap shares its lock and modification counter with its inverse and
+// its key and value views.
+type HashBiMap[K comparable, V comparable] struct {
+	mx   *sync.RWMutex
+	mods *atomic.Int64
+	data collect.BiMap[K, V]
+}
+
+func NewBiMap[K comparable, V comparable]() *HashBiMap[K, V] {
+	return &HashBiMap[K, V]{
+		mx:   &sync.RWMutex{},
+		mods: &atomic.Int64{},
+		data: collect.NewBiMap[K, V](),
+	}
+}
+
+func NewBiMapOf[K comparable, V comparable](data map[K]V) *HashBiMap[K, V] {
+	return &HashBiMap[K, V]{
+		mx:   &sync.RWMutex{},
+		mods: &atomic.Int64{},
+		data: collect.NewBiMapOf(data),
+	}
+}
+
+func (m *HashBiMap[K, V]) Put(key K, value V) bool {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	if m.bound(key, value) {
+		return true
+	}
+	if !m.data.Put(key, value) {
+		return false
+	}
+	m.mods.Add(1)
+	return true
+}
+
+func (m *HashBiMap[K, V]) ForcePut(key K, value V) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	if !m.bound(key, value) {
+		m.data.ForcePut(key, value)
+		m.mods.Add(1)
+	}
+}
+
+func (m *HashBiMap[K, V]) Get(key K) (V, bool) {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return m.data.Get(key)
+}
+
+func (m *HashBiMap[K, V]) Remove(key K) (V, bool) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	value, ok := m.data.Remove(key)
+	if ok {
+		m.mods.Add(1)
+	}
+	return value, ok
+}
+
+func (m *HashBiMap[K, V]) ContainsKey(key K) bool {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return m.data.ContainsKey(key)
+}
+
+func (m *HashBiMap[K, V]) ContainsValue(value V) bool {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return m.data.ContainsValue(value)
+}
+
+func (m *HashBiMap[K, V]) Inverse() collect.BiMap[V, K] {
+	return &HashBiMap[V, K]{
+		mx:   m.mx,
+		mods: m.mods,
+		data: m.data.Inverse(),
+	}
+}
+
+func (m *HashBiMap[K, V]) KeySet() collect.Set[K] {
+	return &lockedSet[K]{mx: m.mx, mods: m.mods, data: m.data.KeySet()}
+}
+
+func (m *HashBiMap[K, V]) Values() collect.Set[V] {
+	return &lockedSet[V]{mx: m.mx, mods: m.mods, data: m.data.Values()}
+}
+
+func (m *HashBiMap[K, V]) Size() int {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return m.data.Size()
+}
+
+func (m *HashBiMap[K, V]) IsEmpty() bool {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return m.data.IsEmpty()
+}
+
+func (m *HashBiMap[K, V]) Clear() {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	m.data.Clear()
+	m.mods.Add(1)
+}
+
+func (m *HashBiMap[K, V]) ForEach(do func(K, V)) {
+	m.mx.RLock()
+	var snapshot []collect.Pair[K, V]
+	m.data.ForEach(func(key K, value V) {
+		snapshot = append(snapshot, collect.Pair[K, V]{First: key, Second: value})
+	})
+	expected := m.mods.Load()
+	m.mx.RUnlock()
+	forEach(snapshot, m.mods, expected, func(entry collect.Pair[K, V]) {
+		do(entry.First, entry.Second)
+	})
+}
+
+func (m *HashBiMap[K, V]) String() string {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return m.data.String()
+}
+
+func (m *HashBiMap[K, V]) bound(key K, value V) bool {
+	bound, ok := m.data.Get(key)
+	return ok && bound == value
+}
+
+type lockedSet[T comparable] struct {
+	mx   *sync.RWMutex
+	mods *atomic.Int64
+	data collect.Set[T]
+}
+
+func (s *lockedSet[T]) Equal(elements collect.Set[T]) bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.data.Equal(elements)
+}
+
+func (s *lockedSet[T]) Add(element T) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.data.Add(element)
+}
+
+func (s *lockedSet[T]) AddAll(elements collect.Collection[T]) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.data.AddAll(elements)
+}
+
+func (s *lockedSet[T]) AddAllSlice(elements []T) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.data.AddAllSlice(elements)
+}
+
+func (s *lockedSet[T]) Contains(element T) bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.data.Contains(element)
+}
+
+func (s *lockedSet[T]) ContainsAll(elements collect.Collection[T]) bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.data.ContainsAll(elements)
+}
+
+func (s *lockedSet[T]) ContainsAllSlice(elements []T) bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.data.ContainsAllSlice(elements)
+}
+
+func (s *lockedSet[T]) Remove(element T) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.modified(s.data.Remove(element))
+}
+
+func (s *lockedSet[T]) RemoveAll(elements collect.Collection[T]) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.modified(s.data.RemoveAll(elements))
+}
+
+func (s *lockedSet[T]) RemoveAllSlice(elements []T) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.modified(s.data.RemoveAllSlice(elements))
+}
+
+func (s *lockedSet[T]) RemoveIf(predicate func(T) bool) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.modified(s.data.RemoveIf(predicate))
+}
+
+func (s *lockedSet[T]) Size() int {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.data.Size()
+}
+
+func (s *lockedSet[T]) IsEmpty() bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.data.IsEmpty()
+}
+
+func (s *lockedSet[T]) Clear() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.data.Clear()
+	s.mods.Add(1)
+}
+
+func (s *lockedSet[T]) Iterator() <-chan T {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.data.Iterator()
+}
+
+func (s *lockedSet[T]) ForEach(do func(T)) {
+	s.mx.RLock()
+	snapshot := make([]T, 0, s.data.Size())
+	for el := range s.data.Iterator() {
+		snapshot = append(snapshot, el)
+	}
+	expected := s.mods.Load()
+	s.mx.RUnlock()
+	forEach(snapshot, s.mods, expected, do)
+}
+
+func (s *lockedSet[T]) String() string {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.data.String()
+}
+
+func (s *lockedSet[T]) modified(modified bool) bool {
+	if modified {
+		s.mods.Add(1)
+	}
+	return modified
+}
@@ -0,0 +1,46 @@
+package blocking
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestBiMap_Concurrent(t *testing.T) {
+	m := NewBiMap[int, int]()
+	inverse := m.Inverse()
+	keys := m.KeySet()
+	values := m.Values()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				key := i*1000 + j
+				m.Put(key, -key)
+				if k, ok := inverse.Get(-key); !ok || k != key {
+					t.Errorf("expected error, key=%d, got=%d", key, k)
+				}
+				if !keys.Contains(key) || !values.Contains(-key) {
+					t.Errorf("expected error, views miss key=%d", key)
+				}
+				if j%2 == 1 {
+					values.Remove(-key)
+				}
+				keys.Size()
+				inverse.Size()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if m.Size() != 4000 || inverse.Size() != 4000 || keys.Size() != 4000 || values.Size() != 4000 {
+		t.Errorf("expected error, size=%d, got=%d", 4000, m.Size())
+	}
+	m.ForEach(func(key, value int) {
+		if key%2 == 1 || value != -key {
+			t.Errorf("expected error, key=%d, value=%d", key, value)
+		}
+	})
+}
@@ -4,17 +4,20 @@ import (
 	"fmt"
 	"github.com/ukrainskiys/go-collections/collect"
 	"sync"
+	"sync/atomic"
 )
 
 type collectionWithSlice[T comparable] struct {
 	mx   *sync.RWMutex
 	data *[]T
+	mods atomic.Int64
 }
 
 func (c *collectionWithSlice[T]) Add(element T) {
 	c.mx.Lock()
 	defer c.mx.Unlock()
 	*c.data = append(*c.data, element)
+	c.mods.Add(1)
 }
 
 func (c *collectionWithSlice[T]) AddAll(elements collect.Collection[T]) {
@@ -72,6 +75,7 @@ func (c *collectionWithSlice[T]) Remove(element T) bool {
 	for idx, el := range *c.data {
 		if el == element {
 			*c.data = append((*c.data)[:idx], (*c.data)[idx+1:]...)
+			c.mods.Add(1)
 			return true
 		}
 	}
@@ -130,6 +134,7 @@ func (c *collectionWithSlice[T]) Clear() {
 	c.mx.Lock()
 	defer c.mx.Unlock()
 	*c.data = nil
+	c.mods.Add(1)
 }
 
 func (c *collectionWithSlice[T]) Iterator() <-chan T {
@@ -146,11 +151,36 @@ func (c *collectionWithSlice[T]) Iterator() <-chan T {
 }
 
 func (c *collectionWithSlice[T]) ForEach(do func(T)) {
+	c.mx.RLock()
+	snapshot := append([]T(nil), *c.data...)
+	expected := c.mods.Load()
+	c.mx.RUnlock()
+	forEach(snapshot, &c.mods, expected, do)
+}
+
+// Iter walks a snapshot and panics with collect.ConcurrentModificationError
+// once the collection was changed other than through the iterator's Remove.
+// Remove deletes the current element by its position, so equal elements
+// elsewhere in the collection are kept.
+func (c *collectionWithSlice[T]) Iter() collect.Iterator[T] {
 	c.mx.RLock()
 	defer c.mx.RUnlock()
-	for _, val := range *c.data {
-		do(val)
-	}
+	expected := c.mods.Load()
+	return collect.NewIterator(append([]T(nil), *c.data...), c.modifications, func(index int, _ T) {
+		c.mx.Lock()
+		defer c.mx.Unlock()
+		// The iterator checked mods before calling remove; check again under
+		// the lock, so that a concurrent writer cannot shift index.
+		if actual := c.mods.Load(); actual != expected {
+			panic(&collect.ConcurrentModificationError{Expected: int(expected), Actual: int(actual)})
+		}
+		*c.data = append((*c.data)[:index], (*c.data)[index+1:]...)
+		expected = c.mods.Add(1)
+	})
+}
+
+func (c *collectionWithSlice[T]) modifications() int {
+	return int(c.mods.Load())
 }
 
 func (c *collectionWithSlice[T]) String() string {
@@ -158,3 +188,15 @@ func (c *collectionWithSlice[T]) String() string {
 	defer c.mx.RUnlock()
 	return fmt.Sprint(*c.data)
 }
+
+// forEach calls do for every element of a snapshot taken together with
+// expected, so that do runs without the lock, and panics with
+// collect.ConcurrentModificationError once mods moved on.
+func forEach[T any](snapshot []T, mods *atomic.Int64, expected int64, do func(T)) {
+	for _, el := range snapshot {
+		do(el)
+		if actual := mods.Load(); actual != expected {
+			panic(&collect.ConcurrentModificationError{Expected: int(expected), Actual: int(actual)})
+		}
+	}
+}
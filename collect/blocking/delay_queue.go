@@ -0,0 +1,203 @@
+package blocking
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer delivers the time on C once it fires. Stop releases it early and
+// reports whether it had not fired yet.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type delayed[T any] struct {
+	element  T
+	deadline time.Time
+	seq      uint64
+}
+
+type delayHeap[T any] []delayed[T]
+
+func (h delayHeap[T]) Len() int { return len(h) }
+func (h delayHeap[T]) Less(i, j int) bool {
+	if h[i].deadline.Equal(h[j].deadline) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].deadline.Before(h[j].deadline)
+}
+func (h delayHeap[T]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *delayHeap[T]) Push(x any) {
+	*h = append(*h, x.(delayed[T]))
+}
+
+func (h *delayHeap[T]) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = delayed[T]{}
+	*h = old[:len(old)-1]
+	return item
+}
+
+// DelayQueue releases elements only once their deadline has passed. Elements
+// with equal deadlines are released in insertion order.
+type DelayQueue[T any] struct {
+	mx      *sync.Mutex
+	data    delayHeap[T]
+	seq     uint64
+	clock   Clock
+	changed chan struct{}
+}
+
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return NewDelayQueueWithClock[T](SystemClock())
+}
+
+func NewDelayQueueWithClock[T any](clock Clock) *DelayQueue[T] {
+	return &DelayQueue[T]{
+		mx:      &sync.Mutex{},
+		clock:   clock,
+		changed: make(chan struct{}),
+	}
+}
+
+func (q *DelayQueue[T]) Offer(element T, releaseAt time.Time) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	heap.Push(&q.data, delayed[T]{element: element, deadline: releaseAt, seq: q.seq})
+	q.seq++
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// Take waits until the head of the queue is released and removes it.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		result, ok, err := q.await(ctx)
+		if ok || err != nil {
+			return result, err
+		}
+	}
+}
+
+// await takes the head if it is released, or otherwise waits once for it to
+// be released, for an Offer or for ctx. The timer is stopped on return, so a
+// wake-up by Offer does not leave it running until the old deadline.
+func (q *DelayQueue[T]) await(ctx context.Context) (T, bool, error) {
+	var t T
+	q.mx.Lock()
+	changed := q.changed
+	var released <-chan time.Time
+	if len(q.data) > 0 {
+		delay := q.data[0].deadline.Sub(q.clock.Now())
+		if delay <= 0 {
+			result := heap.Pop(&q.data).(delayed[T]).element
+			q.mx.Unlock()
+			return result, true, nil
+		}
+		timer := q.clock.NewTimer(delay)
+		defer timer.Stop()
+		released = timer.C()
+	}
+	q.mx.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return t, false, ctx.Err()
+	case <-changed:
+	case <-released:
+	}
+	return t, false, nil
+}
+
+// Pipe offers every element received from src with the deadline returned by
+// releaseAt.
+func (q *DelayQueue[T]) Pipe(ctx context.Context, src <-chan T, releaseAt func(T) time.Time) error {
+	return pipe(ctx, src, func(_ context.Context, el T) error {
+		q.Offer(el, releaseAt(el))
+		return nil
+	})
+}
+
+// Feed returns a channel of elements in release order that is closed once
+// ctx is done.
+func (q *DelayQueue[T]) Feed(ctx context.Context) <-chan T {
+	return feed(ctx, restoring(q.Take, func(el T) {
+		// The zero deadline puts the element back at the head, where it
+		// was taken from.
+		q.mx.Lock()
+		defer q.mx.Unlock()
+		heap.Push(&q.data, delayed[T]{element: el})
+		close(q.changed)
+		q.changed = make(chan struct{})
+	}))
+}
+
+// Poll removes the head of the queue only if it is already released.
+func (q *DelayQueue[T]) Poll() (T, bool) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	if len(q.data) == 0 || q.data[0].deadline.After(q.clock.Now()) {
+		var t T
+		return t, false
+	}
+	return heap.Pop(&q.data).(delayed[T]).element, true
+}
+
+// Peek returns the head of the queue and its deadline, released or not.
+func (q *DelayQueue[T]) Peek() (T, time.Time, bool) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	if len(q.data) == 0 {
+		var t T
+		return t, time.Time{}, false
+	}
+	return q.data[0].element, q.data[0].deadline, true
+}
+
+func (q *DelayQueue[T]) Size() int {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	return len(q.data)
+}
+
+func (q *DelayQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+func (q *DelayQueue[T]) Clear() {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	q.data = nil
+}
@@ -0,0 +1,167 @@
+package blocking
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mx     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock    *fakeClock
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	timer := &fakeTimer{clock: c, deadline: c.now.Add(d), ch: make(chan time.Time, 1)}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.now = c.now.Add(d)
+	waiting := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.deadline.After(c.now) {
+			waiting = append(waiting, timer)
+		} else {
+			timer.ch <- c.now
+		}
+	}
+	c.timers = waiting
+}
+
+func (c *fakeClock) Pending() int {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return len(c.timers)
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mx.Lock()
+	defer t.clock.mx.Unlock()
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func TestDelayQueue_Take(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	queue := NewDelayQueueWithClock[string](clock)
+	queue.Offer("late", clock.Now().Add(time.Hour))
+	queue.Offer("early", clock.Now().Add(time.Minute))
+
+	if el, deadline, _ := queue.Peek(); el != "early" || !deadline.Equal(clock.Now().Add(time.Minute)) {
+		t.Errorf("expected error, head=%s, got=%s", "early", el)
+	}
+	if _, ok := queue.Poll(); ok {
+		t.Errorf("expected error, polled unreleased element")
+	}
+
+	taken := make(chan string)
+	go func() {
+		el, _ := queue.Take(context.Background())
+		taken <- el
+	}()
+	select {
+	case el := <-taken:
+		t.Fatalf("expected error, released too early %s", el)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(time.Minute)
+	select {
+	case el := <-taken:
+		if el != "early" {
+			t.Errorf("expected error, element=%s, got=%s", "early", el)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected error, Take not released")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := queue.Take(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
+
+func TestDelayQueue_EarlierOffer(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	queue := NewDelayQueueWithClock[int](clock)
+	taken := make(chan int)
+	go func() {
+		el, _ := queue.Take(context.Background())
+		taken <- el
+	}()
+
+	queue.Offer(1, clock.Now().Add(time.Hour))
+	waitFor(t, func() bool {
+		return clock.Pending() == 1
+	})
+	queue.Offer(2, clock.Now())
+	select {
+	case el := <-taken:
+		if el != 2 {
+			t.Errorf("expected error, element=%d, got=%d", 2, el)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected error, Take not woken by earlier Offer")
+	}
+	if pending := clock.Pending(); pending != 0 {
+		t.Errorf("expected error, timers=%d, got=%d", 0, pending)
+	}
+}
+
+func TestDelayQueue_PipeFeed(t *testing.T) {
+	queue := NewDelayQueue[int]()
+	start := time.Now()
+	src := make(chan int, 3)
+	src <- 3
+	src <- 1
+	src <- 2
+	close(src)
+	err := queue.Pipe(context.Background(), src, func(el int) time.Time {
+		return start.Add(time.Duration(el) * time.Millisecond)
+	})
+	if err != nil {
+		t.Errorf("expected error, got=%v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	feed := queue.Feed(ctx)
+	for _, expected := range []int{1, 2, 3} {
+		if el := <-feed; el != expected {
+			t.Errorf("expected error, element=%d, got=%d", expected, el)
+		}
+	}
+	cancel()
+	if _, ok := <-feed; ok {
+		t.Errorf("expected error, feed not closed")
+	}
+}
@@ -0,0 +1,50 @@
+package blocking
+
+import (
+	"context"
+	"sync"
+)
+
+type exchangeSlot[T any] struct {
+	offered T
+	reply   chan T
+}
+
+// Exchanger pairs up goroutines calling Exchange, and swaps their values.
+type Exchanger[T any] struct {
+	mx   *sync.Mutex
+	slot *exchangeSlot[T]
+}
+
+func NewExchanger[T any]() *Exchanger[T] {
+	return &Exchanger[T]{mx: &sync.Mutex{}}
+}
+
+func (e *Exchanger[T]) Exchange(ctx context.Context, value T) (T, error) {
+	e.mx.Lock()
+	if waiting := e.slot; waiting != nil {
+		e.slot = nil
+		e.mx.Unlock()
+		waiting.reply <- value
+		return waiting.offered, nil
+	}
+	slot := &exchangeSlot[T]{offered: value, reply: make(chan T, 1)}
+	e.slot = slot
+	e.mx.Unlock()
+
+	select {
+	case other := <-slot.reply:
+		return other, nil
+	case <-ctx.Done():
+		e.mx.Lock()
+		if e.slot == slot {
+			e.slot = nil
+			e.mx.Unlock()
+			var t T
+			return t, ctx.Err()
+		}
+		e.mx.Unlock()
+		// A partner took the slot before we could withdraw it.
+		return <-slot.reply, nil
+	}
+}
@@ -0,0 +1,68 @@
+package blocking
+
+import (
+	"testing"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func expectConcurrentModification(t *testing.T, do func()) {
+	t.Helper()
+	defer func() {
+		if _, ok := recover().(*collect.ConcurrentModificationError); !ok {
+			t.Errorf("expected error, ForEach did not panic")
+		}
+	}()
+	do()
+}
+
+func TestForEach_Modification(t *testing.T) {
+	stack := NewStack(1, 2, 3)
+	expectConcurrentModification(t, func() {
+		stack.ForEach(func(el int) {
+			stack.Push(el)
+		})
+	})
+
+	set := NewSet(1, 2, 3)
+	expectConcurrentModification(t, func() {
+		set.ForEach(func(el int) {
+			set.Remove(el)
+		})
+	})
+
+	sorted := NewSkipListSet(1, 2, 3)
+	expectConcurrentModification(t, func() {
+		sorted.ForEach(func(el int) {
+			sorted.Add(el + 10)
+		})
+	})
+
+	m := NewBiMapOf(map[int]string{1: "a", 2: "b"})
+	expectConcurrentModification(t, func() {
+		m.Values().ForEach(func(value string) {
+			m.Put(3, "c")
+		})
+	})
+}
+
+func TestHashSet_RemoveReportsPresence(t *testing.T) {
+	set := NewSet(1, 2, 3)
+	it := set.Iter()
+	if set.Remove(4) {
+		t.Errorf("expected error, removed absent element")
+	}
+	set.AddAllSlice([]int{1, 2})
+	set.AddAll(collect.NewList(3))
+	it.Next()
+
+	if !set.RemoveAllSlice([]int{1, 4}) || set.RemoveAll(collect.NewList(4)) || !set.RemoveIf(func(el int) bool { return el == 2 }) {
+		t.Errorf("expected error, set=%s", set)
+	}
+	if !set.Equal(NewSet(3)) {
+		t.Errorf("expected error, set=%s", set)
+	}
+	expectConcurrentModification(t, func() {
+		it.Next()
+	})
+}
@@ -0,0 +1,34 @@
+package blocking
+
+import (
+	"github.com/ukrainskiys/go-collections/collect"
+	"sync"
+	"testing"
+)
+
+func TestObserveQueue_Concurrent(t *testing.T) {
+	queue := collect.ObserveQueue[int](NewQueue[int]())
+	added := 0
+	queue.Subscribe(func(event collect.ChangeEvent[int]) {
+		for _, c := range event.Changes {
+			if c.Kind == collect.Added {
+				added++
+			}
+		}
+	})
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				queue.Offer(i*100 + j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if added != 800 || queue.Size() != 800 {
+		t.Errorf("expected error, added=%d, got=%d", 800, added)
+	}
+}
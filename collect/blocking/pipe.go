@@ -0,0 +1,72 @@
+package blocking
+
+import "context"
+
+// pipe hands every element received from src to put, which is expected to
+// wait while the queue has no room. It returns nil once src is closed, or
+// ctx.Err() if ctx is done first.
+func pipe[T any](ctx context.Context, src <-chan T, put func(context.Context, T) error) error {
+	for {
+		select {
+		case el, ok := <-src:
+			if !ok {
+				return nil
+			}
+			if err := put(ctx, el); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// feedItem is an element on its way to a Feed receiver. settle is called with
+// true once the receiver got the element, and with false if ctx was done or
+// the producer withdrew the element first.
+type feedItem[T any] struct {
+	element   T
+	settle    func(delivered bool)
+	withdrawn <-chan struct{}
+}
+
+// feed streams the elements returned by next into an unbuffered channel, so
+// nothing is taken from the queue before the previous element was received.
+// The channel is closed once ctx is done. An element that next already handed
+// out is settled as not delivered, and the queue gives it back, so cancelling
+// a Feed loses nothing.
+func feed[T any](ctx context.Context, next func(context.Context) (feedItem[T], error)) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			item, err := next(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case out <- item.element:
+				item.settle(true)
+			case <-item.withdrawn:
+				item.settle(false)
+			case <-ctx.Done():
+				item.settle(false)
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// restoring adapts take, which removes the element it returns, for feed:
+// an element that was not delivered is passed to restore.
+func restoring[T any](take func(context.Context) (T, error), restore func(T)) func(context.Context) (feedItem[T], error) {
+	return func(ctx context.Context) (feedItem[T], error) {
+		el, err := take(ctx)
+		return feedItem[T]{element: el, settle: func(delivered bool) {
+			if !delivered {
+				restore(el)
+			}
+		}}, err
+	}
+}
@@ -0,0 +1,131 @@
+package blocking
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// expectFeedKeeps cancels a Feed while it holds the only element and checks
+// that the element was either received or is back in the queue.
+func expectFeedKeeps(t *testing.T, feed func(context.Context) <-chan int, size func() int) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := feed(ctx)
+	waitFor(t, func() bool { return size() == 0 })
+	cancel()
+
+	received := 0
+	for range ch {
+		received++
+	}
+	if received+size() != 1 {
+		t.Errorf("expected error, received=%d, left=%d", received, size())
+	}
+}
+
+func TestFeed_CancelKeepsElement(t *testing.T) {
+	queue := NewQueue(1)
+	expectFeedKeeps(t, queue.Feed, queue.Size)
+
+	stack := NewStack(1)
+	expectFeedKeeps(t, stack.Feed, stack.Size)
+
+	delay := NewDelayQueue[int]()
+	delay.Offer(1, time.Now())
+	expectFeedKeeps(t, delay.Feed, delay.Size)
+
+	transfer := NewTransferQueue(1)
+	expectFeedKeeps(t, transfer.Feed, transfer.Size)
+}
+
+func TestSPSCRingBuffer_FeedCancelKeepsElement(t *testing.T) {
+	buffer := NewSPSCRingBuffer[int](2)
+	buffer.TryOffer(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	feed := buffer.Feed(ctx)
+	cancel()
+	received := 0
+	for range feed {
+		received++
+	}
+	if received+buffer.Size() != 1 {
+		t.Errorf("expected error, received=%d, left=%d", received, buffer.Size())
+	}
+}
+
+func TestFeed_CancelReturnsElementToProducer(t *testing.T) {
+	synchronous := NewSynchronousQueue[int]()
+	put := make(chan error, 1)
+	go func() {
+		put <- synchronous.Put(context.Background(), 1)
+	}()
+	transfer := NewTransferQueue[int]()
+	transferred := make(chan error, 1)
+	go func() {
+		transferred <- transfer.Transfer(context.Background(), 2)
+	}()
+
+	for _, consumer := range []struct {
+		feed func(context.Context) <-chan int
+		take func(context.Context) (int, error)
+		done chan error
+	}{
+		{synchronous.Feed, synchronous.Take, put},
+		{transfer.Feed, transfer.Take, transferred},
+	} {
+		ctx, cancel := context.WithCancel(context.Background())
+		feed := consumer.feed(ctx)
+		cancel()
+		received := 0
+		for range feed {
+			received++
+		}
+		if received == 0 {
+			timeout, stop := context.WithTimeout(context.Background(), time.Second)
+			if _, err := consumer.take(timeout); err != nil {
+				t.Errorf("expected error, element lost: %v", err)
+			}
+			stop()
+		}
+		if err := <-consumer.done; err != nil {
+			t.Errorf("expected error, got=%v", err)
+		}
+	}
+}
+
+func TestTransferQueue_WithdrawFromFeed(t *testing.T) {
+	queue := NewTransferQueue[int]()
+	feedCtx, stopFeed := context.WithCancel(context.Background())
+	defer stopFeed()
+	feed := queue.Feed(feedCtx)
+	waitFor(t, func() bool { return queue.WaitingConsumers() == 1 })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	transferred := make(chan error, 1)
+	go func() {
+		transferred <- queue.Transfer(ctx, 1)
+	}()
+	waitFor(t, func() bool { return queue.WaitingConsumers() == 0 })
+
+	cancel()
+	if err := <-transferred; err != context.Canceled || queue.Size() != 0 {
+		t.Errorf("expected error, got=%v, size=%d", err, queue.Size())
+	}
+	select {
+	case el := <-feed:
+		t.Errorf("expected error, withdrawn element %d delivered", el)
+	default:
+	}
+}
+
+// waitFor yields until cond holds, failing the test after a second.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); runtime.Gosched() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected error, condition not reached")
+		}
+	}
+}
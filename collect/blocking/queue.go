@@ -1,24 +1,72 @@
 package blocking
 
-import "sync"
+import (
+	"context"
+	"sync"
+)
 
 type PrimaryQueue[T comparable] struct {
 	collectionWithSlice[T]
+	nonEmpty *sync.Cond
 }
 
 func NewQueue[T comparable](elements ...T) *PrimaryQueue[T] {
+	mx := &sync.RWMutex{}
 	return &PrimaryQueue[T]{
 		collectionWithSlice: collectionWithSlice[T]{
-			mx:   &sync.RWMutex{},
+			mx:   mx,
 			data: &elements,
 		},
+		nonEmpty: sync.NewCond(mx),
 	}
 }
 
 func (p *PrimaryQueue[T]) Offer(element T) {
 	p.mx.Lock()
 	defer p.mx.Unlock()
-	p.Add(element)
+	*p.data = append(*p.data, element)
+	p.mods.Add(1)
+	p.nonEmpty.Broadcast()
+}
+
+func (p *PrimaryQueue[T]) Add(element T) {
+	p.Offer(element)
+}
+
+// Take waits until the queue is not empty and removes its head.
+func (p *PrimaryQueue[T]) Take(ctx context.Context) (T, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	for len(*p.data) == 0 {
+		if err := ctx.Err(); err != nil {
+			var t T
+			return t, err
+		}
+		waitContext(ctx, p.nonEmpty)
+	}
+	result := (*p.data)[0]
+	*p.data = (*p.data)[1:]
+	p.mods.Add(1)
+	return result, nil
+}
+
+// Pipe offers every element received from src.
+func (p *PrimaryQueue[T]) Pipe(ctx context.Context, src <-chan T) error {
+	return pipe(ctx, src, func(_ context.Context, el T) error {
+		p.Offer(el)
+		return nil
+	})
+}
+
+// Feed returns a channel of taken elements that is closed once ctx is done.
+func (p *PrimaryQueue[T]) Feed(ctx context.Context) <-chan T {
+	return feed(ctx, restoring(p.Take, func(el T) {
+		p.mx.Lock()
+		defer p.mx.Unlock()
+		*p.data = append([]T{el}, *p.data...)
+		p.mods.Add(1)
+		p.nonEmpty.Broadcast()
+	}))
 }
 
 func (p *PrimaryQueue[T]) Pool() T {
@@ -26,6 +74,7 @@ func (p *PrimaryQueue[T]) Pool() T {
 	defer p.mx.Unlock()
 	result := (*p.data)[0]
 	*p.data = (*p.data)[1:]
+	p.mods.Add(1)
 	return result
 }
 
@@ -0,0 +1,83 @@
+package blocking
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestQueue_WaitingTake(t *testing.T) {
+	queue := NewQueue[int]()
+	taken := make(chan int)
+	go func() {
+		el, _ := queue.Take(context.Background())
+		taken <- el
+	}()
+
+	select {
+	case el := <-taken:
+		t.Fatalf("expected error, Take returned %d from an empty queue", el)
+	default:
+	}
+	queue.Offer(1)
+	select {
+	case el := <-taken:
+		if el != 1 {
+			t.Errorf("expected error, element=%d, got=%d", 1, el)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected error, Take not woken by Offer")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := queue.Take(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
+
+func TestQueue_PipeFeed(t *testing.T) {
+	queue := NewQueue[int]()
+	src := make(chan int)
+	go func() {
+		defer close(src)
+		for i := 0; i < 100; i++ {
+			src <- i
+		}
+	}()
+	piped := make(chan error, 1)
+	go func() {
+		piped <- queue.Pipe(context.Background(), src)
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	feed := queue.Feed(ctx)
+	for i := 0; i < 100; i++ {
+		if el := <-feed; el != i {
+			t.Fatalf("expected error, element=%d, got=%d", i, el)
+		}
+	}
+	if err := <-piped; err != nil {
+		t.Errorf("expected error, got=%v", err)
+	}
+	cancel()
+	if _, ok := <-feed; ok {
+		t.Errorf("expected error, feed not closed")
+	}
+}
+
+func TestQueue_IterRemoveDuplicate(t *testing.T) {
+	queue := NewQueue(1, 2, 1, 3)
+	it := queue.Iter()
+	for i := 0; i < 3; i++ {
+		it.Next()
+	}
+	it.Remove()
+	if el := it.Next(); el != 3 {
+		t.Errorf("expected error, element=%d, got=%d", 3, el)
+	}
+	it.Remove()
+	if !queue.Equal(NewQueue(1, 2)) {
+		t.Errorf("expected error, queue=%s", queue)
+	}
+}
@@ -0,0 +1,166 @@
+package blocking
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// SPSCRingBuffer is a fixed-capacity lock-free queue for exactly one producer
+// goroutine and one consumer goroutine. The producer owns tail and the
+// consumer owns head; each only reads the other's index. A side that has to
+// wait parks on a notify channel, which the other side only signals while the
+// matching waiting flag is set, so the fast paths never touch a channel.
+type SPSCRingBuffer[T any] struct {
+	data []T
+	head atomic.Uint64
+	tail atomic.Uint64
+
+	consumerWaiting atomic.Bool
+	producerWaiting atomic.Bool
+	notEmpty        chan struct{}
+	notFull         chan struct{}
+}
+
+func NewSPSCRingBuffer[T any](capacity int) *SPSCRingBuffer[T] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("blocking: non-positive capacity %d", capacity))
+	}
+	return &SPSCRingBuffer[T]{
+		data:     make([]T, capacity),
+		notEmpty: make(chan struct{}, 1),
+		notFull:  make(chan struct{}, 1),
+	}
+}
+
+func (r *SPSCRingBuffer[T]) TryOffer(element T) bool {
+	tail := r.tail.Load()
+	if tail-r.head.Load() == uint64(len(r.data)) {
+		return false
+	}
+	r.data[tail%uint64(len(r.data))] = element
+	r.tail.Store(tail + 1)
+	if r.consumerWaiting.Load() {
+		notify(r.notEmpty)
+	}
+	return true
+}
+
+func (r *SPSCRingBuffer[T]) TryPool() (T, bool) {
+	var zero T
+	head := r.head.Load()
+	if head == r.tail.Load() {
+		return zero, false
+	}
+	idx := head % uint64(len(r.data))
+	result := r.data[idx]
+	r.data[idx] = zero
+	r.head.Store(head + 1)
+	if r.producerWaiting.Load() {
+		notify(r.notFull)
+	}
+	return result, true
+}
+
+// Offer waits until there is room for element or ctx is done.
+func (r *SPSCRingBuffer[T]) Offer(ctx context.Context, element T) error {
+	for !r.TryOffer(element) {
+		// The flag is set before the retry, so a Pool racing with it either
+		// leaves room for the retry or sees the flag and notifies.
+		r.producerWaiting.Store(true)
+		if r.TryOffer(element) {
+			r.producerWaiting.Store(false)
+			return nil
+		}
+		select {
+		case <-r.notFull:
+		case <-ctx.Done():
+		}
+		r.producerWaiting.Store(false)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pool waits until an element is available or ctx is done.
+func (r *SPSCRingBuffer[T]) Pool(ctx context.Context) (T, error) {
+	for {
+		if el, ok := r.TryPool(); ok {
+			return el, nil
+		}
+		if err := r.await(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// await parks the consumer until the buffer is not empty or ctx is done.
+func (r *SPSCRingBuffer[T]) await(ctx context.Context) error {
+	for r.IsEmpty() {
+		r.consumerWaiting.Store(true)
+		if !r.IsEmpty() {
+			r.consumerWaiting.Store(false)
+			return nil
+		}
+		select {
+		case <-r.notEmpty:
+		case <-ctx.Done():
+		}
+		r.consumerWaiting.Store(false)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SPSCRingBuffer[T]) Size() int {
+	head := r.head.Load()
+	return int(r.tail.Load() - head)
+}
+
+func (r *SPSCRingBuffer[T]) IsEmpty() bool {
+	return r.Size() == 0
+}
+
+func (r *SPSCRingBuffer[T]) IsFull() bool {
+	return r.Size() == len(r.data)
+}
+
+func (r *SPSCRingBuffer[T]) Capacity() int {
+	return len(r.data)
+}
+
+// Pipe offers every element received from src, waiting while the buffer is
+// full. It must be the only producer.
+func (r *SPSCRingBuffer[T]) Pipe(ctx context.Context, src <-chan T) error {
+	return pipe(ctx, src, r.Offer)
+}
+
+// Feed returns a channel of pooled elements that is closed once ctx is done.
+// It must be the only consumer. The head is only pooled once the receiver got
+// it, since the consumer cannot put an element back.
+func (r *SPSCRingBuffer[T]) Feed(ctx context.Context) <-chan T {
+	return feed(ctx, func(ctx context.Context) (feedItem[T], error) {
+		if err := r.await(ctx); err != nil {
+			return feedItem[T]{}, err
+		}
+		head := r.head.Load()
+		return feedItem[T]{element: r.data[head%uint64(len(r.data))], settle: func(delivered bool) {
+			if delivered {
+				r.TryPool()
+			}
+		}}, nil
+	})
+}
+
+// notify leaves a wake-up token in ch unless one is already pending.
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
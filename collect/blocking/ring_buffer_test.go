@@ -0,0 +1,89 @@
+package blocking
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSPSCRingBuffer(t *testing.T) {
+	ring := NewSPSCRingBuffer[int](16)
+	ctx := context.Background()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100_000; i++ {
+			if err := ring.Offer(ctx, i); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 100_000; i++ {
+		el, err := ring.Pool(ctx)
+		if err != nil || el != i {
+			t.Fatalf("expected error, element=%d, got=%d", i, el)
+		}
+	}
+	<-done
+
+	if !ring.IsEmpty() {
+		t.Errorf("expected error, buffer not empty")
+	}
+	timeout, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	if _, err := ring.Pool(timeout); err != context.DeadlineExceeded {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
+
+func TestSPSCRingBuffer_PipeFeed(t *testing.T) {
+	buffer := NewSPSCRingBuffer[int](2)
+	src := make(chan int)
+	go func() {
+		defer close(src)
+		for i := 0; i < 100; i++ {
+			src <- i
+		}
+	}()
+	piped := make(chan error, 1)
+	go func() {
+		piped <- buffer.Pipe(context.Background(), src)
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	feed := buffer.Feed(ctx)
+	for i := 0; i < 100; i++ {
+		if el := <-feed; el != i {
+			t.Fatalf("expected error, element=%d, got=%d", i, el)
+		}
+	}
+	if err := <-piped; err != nil {
+		t.Errorf("expected error, got=%v", err)
+	}
+	cancel()
+	if _, ok := <-feed; ok {
+		t.Errorf("expected error, feed not closed")
+	}
+}
+
+func TestSPSCRingBuffer_ParkedPool(t *testing.T) {
+	buffer := NewSPSCRingBuffer[int](1)
+	pooled := make(chan int)
+	go func() {
+		el, _ := buffer.Pool(context.Background())
+		pooled <- el
+	}()
+
+	waitFor(t, buffer.consumerWaiting.Load)
+	buffer.TryOffer(42)
+	select {
+	case el := <-pooled:
+		if el != 42 {
+			t.Errorf("expected error, element=%d, got=%d", 42, el)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected error, parked Pool not woken")
+	}
+}
@@ -5,9 +5,10 @@ import (
 	"github.com/ukrainskiys/go-collections/collect"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
-type Set[T comparable] interface {
+type Set[T any] interface {
 	Equal(elements Set[T]) bool
 	collect.Collection[T]
 }
@@ -15,6 +16,7 @@ type Set[T comparable] interface {
 type HashSet[T comparable] struct {
 	data map[any]interface{}
 	mx   *sync.RWMutex
+	mods atomic.Int64
 }
 
 func NewSet[T comparable](elements ...T) *HashSet[T] {
@@ -80,7 +82,7 @@ func (s *HashSet[T]) ContainsAll(elements collect.Collection[T]) bool {
 	s.mx.RLock()
 	defer s.mx.RUnlock()
 	for el := range elements.Iterator() {
-		if !s.Contains(el) {
+		if _, ok := s.data[el]; !ok {
 			return false
 		}
 	}
@@ -91,7 +93,7 @@ func (s *HashSet[T]) ContainsAllSlice(elements []T) bool {
 	s.mx.RLock()
 	defer s.mx.RUnlock()
 	for _, e := range elements {
-		if !s.Contains(e) {
+		if _, ok := s.data[e]; !ok {
 			return false
 		}
 	}
@@ -101,21 +103,11 @@ func (s *HashSet[T]) ContainsAllSlice(elements []T) bool {
 func (s *HashSet[T]) Remove(element T) bool {
 	s.mx.Lock()
 	defer s.mx.Unlock()
-	delete(s.data, element)
-	_, ok := s.data[element]
-	return !ok
+	return s.remove(element)
 }
 
 func (s *HashSet[T]) RemoveAll(elements collect.Collection[T]) bool {
-	s.mx.Lock()
-	defer s.mx.Unlock()
-	modified := false
-	for el := range elements.Iterator() {
-		if s.Remove(el) {
-			modified = true
-		}
-	}
-	return modified
+	return s.RemoveAllSlice(*collect.NewListOf(elements).Slice())
 }
 
 func (s *HashSet[T]) RemoveAllSlice(elements []T) bool {
@@ -123,7 +115,7 @@ func (s *HashSet[T]) RemoveAllSlice(elements []T) bool {
 	defer s.mx.Unlock()
 	modified := false
 	for _, e := range elements {
-		if s.Remove(e) {
+		if s.remove(e) {
 			modified = true
 		}
 	}
@@ -135,43 +127,41 @@ func (s *HashSet[T]) RemoveIf(predicate func(T) bool) bool {
 	defer s.mx.Unlock()
 	modified := false
 	for key := range s.data {
-		if predicate(key.(T)) {
-			if s.Remove(key.(T)) {
-				modified = true
-			}
+		if predicate(key.(T)) && s.remove(key.(T)) {
+			modified = true
 		}
 	}
 	return modified
 }
 
 func (s *HashSet[T]) Add(element T) {
-	s.mx.RLock()
-	defer s.mx.RUnlock()
-	s.data[element] = nil
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.add(element)
 }
 
 func (s *HashSet[T]) AddAll(elements collect.Collection[T]) {
-	s.mx.RLock()
-	defer s.mx.RUnlock()
-	for el := range elements.Iterator() {
-		s.data[el] = nil
-	}
+	s.AddAllSlice(*collect.NewListOf(elements).Slice())
 }
 
 func (s *HashSet[T]) AddAllSlice(elements []T) {
-	s.mx.RLock()
-	defer s.mx.RUnlock()
+	s.mx.Lock()
+	defer s.mx.Unlock()
 	for _, el := range elements {
-		s.data[el] = nil
+		s.add(el)
 	}
 }
 
 func (s *HashSet[T]) Clear() {
 	s.mx.Lock()
 	defer s.mx.Unlock()
+	if len(s.data) == 0 {
+		return
+	}
 	for k := range s.data {
 		delete(s.data, k)
 	}
+	s.mods.Add(1)
 }
 
 func (s *HashSet[T]) Iterator() <-chan T {
@@ -188,11 +178,23 @@ func (s *HashSet[T]) Iterator() <-chan T {
 }
 
 func (s *HashSet[T]) ForEach(do func(T)) {
+	s.mx.RLock()
+	snapshot := s.snapshot()
+	expected := s.mods.Load()
+	s.mx.RUnlock()
+	forEach(snapshot, &s.mods, expected, do)
+}
+
+// Iter walks a snapshot and panics with collect.ConcurrentModificationError
+// once the set was changed other than through the iterator's Remove.
+func (s *HashSet[T]) Iter() collect.Iterator[T] {
 	s.mx.RLock()
 	defer s.mx.RUnlock()
-	for key := range s.data {
-		do(key.(T))
-	}
+	return collect.NewIterator(s.snapshot(), func() int {
+		return int(s.mods.Load())
+	}, func(_ int, el T) {
+		s.Remove(el)
+	})
 }
 
 func (s *HashSet[T]) String() string {
@@ -204,3 +206,27 @@ func (s *HashSet[T]) String() string {
 	}
 	return "[" + strings.Join(data, " ") + "]"
 }
+
+func (s *HashSet[T]) snapshot() []T {
+	data := make([]T, 0, len(s.data))
+	for key := range s.data {
+		data = append(data, key.(T))
+	}
+	return data
+}
+
+func (s *HashSet[T]) add(element T) {
+	if _, ok := s.data[element]; !ok {
+		s.data[element] = nil
+		s.mods.Add(1)
+	}
+}
+
+func (s *HashSet[T]) remove(element T) bool {
+	if _, ok := s.data[element]; !ok {
+		return false
+	}
+	delete(s.data, element)
+	s.mods.Add(1)
+	return true
+}
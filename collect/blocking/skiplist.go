@@ -0,0 +1,401 @@
+package blocking
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+const (
+	skipListMaxLevel = 32
+	skipListP        = 0.25
+)
+
+type SkipListEntry[K collect.Ordered, V any] struct {
+	Key   K
+	Value V
+}
+
+type skipListNode[K collect.Ordered, V any] struct {
+	key     K
+	value   atomic.Pointer[V]
+	next    []atomic.Pointer[skipListNode[K, V]]
+	span    []int
+	removed atomic.Bool
+}
+
+func newSkipListNode[K collect.Ordered, V any](key K, value V, level int) *skipListNode[K, V] {
+	n := &skipListNode[K, V]{
+		key:  key,
+		next: make([]atomic.Pointer[skipListNode[K, V]], level),
+		span: make([]int, level),
+	}
+	n.value.Store(&value)
+	return n
+}
+
+func (n *skipListNode[K, V]) load() V {
+	return *n.value.Load()
+}
+
+// skipList is an indexable skip list: span[i] counts the nodes skipped by
+// next[i], which makes rank queries logarithmic. Writers must be serialized
+// by the caller. Links, values, the level and the length are atomic, so
+// lookups and ordered walks may run alongside a writer: nodes are linked
+// bottom-up and unlinked top-down, and a removed node keeps its links, so a
+// reader standing on it still finds its way forward. Spans are not atomic;
+// rank queries must exclude writers.
+type skipList[K collect.Ordered, V any] struct {
+	head   *skipListNode[K, V]
+	level  atomic.Int32
+	length atomic.Int64
+	mods   atomic.Int64
+	random *rand.Rand
+}
+
+func newSkipList[K collect.Ordered, V any]() *skipList[K, V] {
+	var zero V
+	l := &skipList[K, V]{
+		head:   newSkipListNode[K, V](*new(K), zero, skipListMaxLevel),
+		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	l.level.Store(1)
+	return l
+}
+
+func (l *skipList[K, V]) put(key K, value V) (V, bool) {
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	var rank [skipListMaxLevel]int
+	level := int(l.level.Load())
+	x := l.head
+	for i := level - 1; i >= 0; i-- {
+		if i < level-1 {
+			rank[i] = rank[i+1]
+		}
+		for next := x.next[i].Load(); next != nil && next.key < key; next = x.next[i].Load() {
+			rank[i] += x.span[i]
+			x = next
+		}
+		update[i] = x
+	}
+	if next := x.next[0].Load(); next != nil && next.key == key {
+		return *next.value.Swap(&value), true
+	}
+
+	nodeLevel := l.randomLevel()
+	for i := level; i < nodeLevel; i++ {
+		rank[i] = 0
+		update[i] = l.head
+		update[i].span[i] = int(l.length.Load())
+	}
+
+	n := newSkipListNode(key, value, nodeLevel)
+	for i := 0; i < nodeLevel; i++ {
+		n.next[i].Store(update[i].next[i].Load())
+		n.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+		update[i].next[i].Store(n)
+	}
+	for i := nodeLevel; i < level; i++ {
+		update[i].span[i]++
+	}
+	if nodeLevel > level {
+		l.level.Store(int32(nodeLevel))
+	}
+	l.length.Add(1)
+	l.mods.Add(1)
+
+	var zero V
+	return zero, false
+}
+
+func (l *skipList[K, V]) remove(key K) (V, bool) {
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	level := int(l.level.Load())
+	x := l.head
+	for i := level - 1; i >= 0; i-- {
+		for next := x.next[i].Load(); next != nil && next.key < key; next = x.next[i].Load() {
+			x = next
+		}
+		update[i] = x
+	}
+	x = x.next[0].Load()
+	if x == nil || x.key != key {
+		var zero V
+		return zero, false
+	}
+
+	x.removed.Store(true)
+	for i := level - 1; i >= 0; i-- {
+		if update[i].next[i].Load() == x {
+			update[i].span[i] += x.span[i] - 1
+			update[i].next[i].Store(x.next[i].Load())
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for level > 1 && l.head.next[level-1].Load() == nil {
+		level--
+	}
+	l.level.Store(int32(level))
+	l.length.Add(-1)
+	l.mods.Add(1)
+	return x.load(), true
+}
+
+func (l *skipList[K, V]) get(key K) (*skipListNode[K, V], bool) {
+	x := l.ceiling(key)
+	return x, x != nil && x.key == key
+}
+
+// ceiling returns the first node with a key >= key.
+func (l *skipList[K, V]) ceiling(key K) *skipListNode[K, V] {
+	x := l.head
+	for i := int(l.level.Load()) - 1; i >= 0; i-- {
+		for next := x.next[i].Load(); next != nil && next.key < key; next = x.next[i].Load() {
+			x = next
+		}
+	}
+	return l.live(x.next[0].Load())
+}
+
+func (l *skipList[K, V]) first() *skipListNode[K, V] {
+	return l.live(l.head.next[0].Load())
+}
+
+func (l *skipList[K, V]) following(n *skipListNode[K, V]) *skipListNode[K, V] {
+	return l.live(n.next[0].Load())
+}
+
+// live skips the nodes a concurrent writer has removed but a reader may
+// still reach.
+func (l *skipList[K, V]) live(n *skipListNode[K, V]) *skipListNode[K, V] {
+	for n != nil && n.removed.Load() {
+		n = n.next[0].Load()
+	}
+	return n
+}
+
+func (l *skipList[K, V]) last() *skipListNode[K, V] {
+	for {
+		x := l.head
+		for i := int(l.level.Load()) - 1; i >= 0; i-- {
+			for next := x.next[i].Load(); next != nil; next = x.next[i].Load() {
+				x = next
+			}
+		}
+		if x == l.head {
+			return nil
+		}
+		// A removed last node is being unlinked by a writer; look again.
+		if !x.removed.Load() {
+			return x
+		}
+	}
+}
+
+func (l *skipList[K, V]) rank(key K) int {
+	x := l.head
+	rank := 0
+	for i := int(l.level.Load()) - 1; i >= 0; i-- {
+		for next := x.next[i].Load(); next != nil && next.key <= key; next = x.next[i].Load() {
+			rank += x.span[i]
+			x = next
+		}
+		if x != l.head && x.key == key {
+			return rank - 1
+		}
+	}
+	return -1
+}
+
+func (l *skipList[K, V]) at(index int) *skipListNode[K, V] {
+	if index < 0 || index >= int(l.length.Load()) {
+		return nil
+	}
+	target := index + 1
+	traversed := 0
+	x := l.head
+	for i := int(l.level.Load()) - 1; i >= 0; i-- {
+		for next := x.next[i].Load(); next != nil && traversed+x.span[i] <= target; next = x.next[i].Load() {
+			traversed += x.span[i]
+			x = next
+		}
+		if traversed == target {
+			return x
+		}
+	}
+	return nil
+}
+
+func (l *skipList[K, V]) size() int {
+	return int(l.length.Load())
+}
+
+func (l *skipList[K, V]) clear() {
+	for i := range l.head.next {
+		l.head.next[i].Store(nil)
+		l.head.span[i] = 0
+	}
+	l.level.Store(1)
+	l.length.Store(0)
+	l.mods.Add(1)
+}
+
+func (l *skipList[K, V]) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && l.random.Float64() < skipListP {
+		level++
+	}
+	return level
+}
+
+// ConcurrentSkipListMap is a sorted map safe for concurrent use. Get,
+// ContainsKey, First, Last, the range queries, Size and String never lock and
+// run alongside writers. Writers are serialized by mx. IndexOf and EntryAt
+// read rank counts that writers update in place, so they take the read lock,
+// as do ForEach and Iter to pair their snapshot with the modification count.
+type ConcurrentSkipListMap[K collect.Ordered, V any] struct {
+	mx   *sync.RWMutex
+	data *skipList[K, V]
+}
+
+func NewSkipListMap[K collect.Ordered, V any]() *ConcurrentSkipListMap[K, V] {
+	return &ConcurrentSkipListMap[K, V]{
+		mx:   &sync.RWMutex{},
+		data: newSkipList[K, V](),
+	}
+}
+
+func (m *ConcurrentSkipListMap[K, V]) Put(key K, value V) (V, bool) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	return m.data.put(key, value)
+}
+
+func (m *ConcurrentSkipListMap[K, V]) Get(key K) (V, bool) {
+	if n, ok := m.data.get(key); ok {
+		return n.load(), true
+	}
+	var zero V
+	return zero, false
+}
+
+func (m *ConcurrentSkipListMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.data.get(key)
+	return ok
+}
+
+func (m *ConcurrentSkipListMap[K, V]) Remove(key K) (V, bool) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	return m.data.remove(key)
+}
+
+func (m *ConcurrentSkipListMap[K, V]) First() (SkipListEntry[K, V], bool) {
+	return entryOf(m.data.first())
+}
+
+func (m *ConcurrentSkipListMap[K, V]) Last() (SkipListEntry[K, V], bool) {
+	return entryOf(m.data.last())
+}
+
+func (m *ConcurrentSkipListMap[K, V]) PollFirst() (SkipListEntry[K, V], bool) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	entry, ok := entryOf(m.data.first())
+	if ok {
+		m.data.remove(entry.Key)
+	}
+	return entry, ok
+}
+
+func (m *ConcurrentSkipListMap[K, V]) PollLast() (SkipListEntry[K, V], bool) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	entry, ok := entryOf(m.data.last())
+	if ok {
+		m.data.remove(entry.Key)
+	}
+	return entry, ok
+}
+
+// From returns the entries with keys >= lo in ascending order.
+func (m *ConcurrentSkipListMap[K, V]) From(lo K) *collect.AnyList[SkipListEntry[K, V]] {
+	return m.collect(m.data.ceiling(lo), func(K) bool { return true })
+}
+
+// To returns the entries with keys < hi in ascending order.
+func (m *ConcurrentSkipListMap[K, V]) To(hi K) *collect.AnyList[SkipListEntry[K, V]] {
+	return m.collect(m.data.first(), func(key K) bool { return key < hi })
+}
+
+// Between returns the entries with lo <= key < hi in ascending order.
+func (m *ConcurrentSkipListMap[K, V]) Between(lo, hi K) *collect.AnyList[SkipListEntry[K, V]] {
+	return m.collect(m.data.ceiling(lo), func(key K) bool { return key < hi })
+}
+
+// IndexOf returns the zero-based rank of key, or -1 if it is absent.
+func (m *ConcurrentSkipListMap[K, V]) IndexOf(key K) int {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return m.data.rank(key)
+}
+
+func (m *ConcurrentSkipListMap[K, V]) EntryAt(rank int) (SkipListEntry[K, V], bool) {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return entryOf(m.data.at(rank))
+}
+
+func (m *ConcurrentSkipListMap[K, V]) Size() int {
+	return m.data.size()
+}
+
+func (m *ConcurrentSkipListMap[K, V]) IsEmpty() bool {
+	return m.data.size() == 0
+}
+
+func (m *ConcurrentSkipListMap[K, V]) Clear() {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	m.data.clear()
+}
+
+func (m *ConcurrentSkipListMap[K, V]) ForEach(do func(K, V)) {
+	m.mx.RLock()
+	snapshot := *m.collect(m.data.first(), func(K) bool { return true }).Slice()
+	expected := m.data.mods.Load()
+	m.mx.RUnlock()
+	forEach(snapshot, &m.data.mods, expected, func(entry SkipListEntry[K, V]) {
+		do(entry.Key, entry.Value)
+	})
+}
+
+func (m *ConcurrentSkipListMap[K, V]) String() string {
+	var data []string
+	for x := m.data.first(); x != nil; x = m.data.following(x) {
+		data = append(data, fmt.Sprintf("%v:%v", x.key, x.load()))
+	}
+	return "{" + strings.Join(data, " ") + "}"
+}
+
+func (m *ConcurrentSkipListMap[K, V]) collect(from *skipListNode[K, V], while func(K) bool) *collect.AnyList[SkipListEntry[K, V]] {
+	result := collect.NewAnyList[SkipListEntry[K, V]]()
+	for x := from; x != nil && while(x.key); x = m.data.following(x) {
+		result.Add(SkipListEntry[K, V]{Key: x.key, Value: x.load()})
+	}
+	return result
+}
+
+func entryOf[K collect.Ordered, V any](n *skipListNode[K, V]) (SkipListEntry[K, V], bool) {
+	if n == nil {
+		return SkipListEntry[K, V]{}, false
+	}
+	return SkipListEntry[K, V]{Key: n.key, Value: n.load()}, true
+}
@@ -0,0 +1,253 @@
+package blocking
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+// ConcurrentSkipListSet is a sorted set safe for concurrent use. Like
+// ConcurrentSkipListMap, lookups, range queries and ordered walks never lock,
+// while IndexOf, ElementAt, ForEach and Iter take the read lock.
+type ConcurrentSkipListSet[T collect.Ordered] struct {
+	mx   *sync.RWMutex
+	data *skipList[T, struct{}]
+}
+
+func NewSkipListSet[T collect.Ordered](elements ...T) *ConcurrentSkipListSet[T] {
+	set := &ConcurrentSkipListSet[T]{
+		mx:   &sync.RWMutex{},
+		data: newSkipList[T, struct{}](),
+	}
+	for _, el := range elements {
+		set.data.put(el, struct{}{})
+	}
+	return set
+}
+
+func NewSkipListSetOf[T collect.Ordered](elements collect.Collection[T]) *ConcurrentSkipListSet[T] {
+	set := NewSkipListSet[T]()
+	for el := range elements.Iterator() {
+		set.data.put(el, struct{}{})
+	}
+	return set
+}
+
+func (s *ConcurrentSkipListSet[T]) First() (T, bool) {
+	return keyOf(s.data.first())
+}
+
+func (s *ConcurrentSkipListSet[T]) Last() (T, bool) {
+	return keyOf(s.data.last())
+}
+
+func (s *ConcurrentSkipListSet[T]) PollFirst() (T, bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	key, ok := keyOf(s.data.first())
+	if ok {
+		s.data.remove(key)
+	}
+	return key, ok
+}
+
+func (s *ConcurrentSkipListSet[T]) PollLast() (T, bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	key, ok := keyOf(s.data.last())
+	if ok {
+		s.data.remove(key)
+	}
+	return key, ok
+}
+
+// From returns the elements >= lo in ascending order.
+func (s *ConcurrentSkipListSet[T]) From(lo T) *collect.AnyList[T] {
+	return s.collect(s.data.ceiling(lo), func(T) bool { return true })
+}
+
+// To returns the elements < hi in ascending order.
+func (s *ConcurrentSkipListSet[T]) To(hi T) *collect.AnyList[T] {
+	return s.collect(s.data.first(), func(el T) bool { return el < hi })
+}
+
+// Between returns the elements in [lo, hi) in ascending order.
+func (s *ConcurrentSkipListSet[T]) Between(lo, hi T) *collect.AnyList[T] {
+	return s.collect(s.data.ceiling(lo), func(el T) bool { return el < hi })
+}
+
+// IndexOf returns the zero-based rank of element, or -1 if it is absent.
+func (s *ConcurrentSkipListSet[T]) IndexOf(element T) int {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.data.rank(element)
+}
+
+func (s *ConcurrentSkipListSet[T]) ElementAt(rank int) (T, bool) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return keyOf(s.data.at(rank))
+}
+
+func (s *ConcurrentSkipListSet[T]) Equal(elements collect.Set[T]) bool {
+	if elements == nil {
+		return false
+	}
+	if s.data.size() != elements.Size() {
+		return false
+	}
+
+	for x := s.data.first(); x != nil; x = s.data.following(x) {
+		if !elements.Contains(x.key) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *ConcurrentSkipListSet[T]) Add(element T) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.data.put(element, struct{}{})
+}
+
+func (s *ConcurrentSkipListSet[T]) AddAll(elements collect.Collection[T]) {
+	s.AddAllSlice(*collect.NewListOf(elements).Slice())
+}
+
+func (s *ConcurrentSkipListSet[T]) AddAllSlice(elements []T) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for _, el := range elements {
+		s.data.put(el, struct{}{})
+	}
+}
+
+func (s *ConcurrentSkipListSet[T]) Contains(element T) bool {
+	_, ok := s.data.get(element)
+	return ok
+}
+
+func (s *ConcurrentSkipListSet[T]) ContainsAll(elements collect.Collection[T]) bool {
+	return s.ContainsAllSlice(*collect.NewListOf(elements).Slice())
+}
+
+func (s *ConcurrentSkipListSet[T]) ContainsAllSlice(elements []T) bool {
+	for _, el := range elements {
+		if _, ok := s.data.get(el); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *ConcurrentSkipListSet[T]) Remove(element T) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	_, ok := s.data.remove(element)
+	return ok
+}
+
+func (s *ConcurrentSkipListSet[T]) RemoveAll(elements collect.Collection[T]) bool {
+	return s.RemoveAllSlice(*collect.NewListOf(elements).Slice())
+}
+
+func (s *ConcurrentSkipListSet[T]) RemoveAllSlice(elements []T) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	modified := false
+	for _, el := range elements {
+		if _, ok := s.data.remove(el); ok {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (s *ConcurrentSkipListSet[T]) RemoveIf(predicate func(T) bool) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	var removed []T
+	for x := s.data.first(); x != nil; x = s.data.following(x) {
+		if predicate(x.key) {
+			removed = append(removed, x.key)
+		}
+	}
+	for _, el := range removed {
+		s.data.remove(el)
+	}
+	return len(removed) > 0
+}
+
+func (s *ConcurrentSkipListSet[T]) Size() int {
+	return s.data.size()
+}
+
+func (s *ConcurrentSkipListSet[T]) IsEmpty() bool {
+	return s.data.size() == 0
+}
+
+func (s *ConcurrentSkipListSet[T]) Clear() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.data.clear()
+}
+
+func (s *ConcurrentSkipListSet[T]) Iterator() <-chan T {
+	elements := *s.collect(s.data.first(), func(T) bool { return true }).Slice()
+	pool := make(chan T, len(elements))
+	defer close(pool)
+
+	for _, el := range elements {
+		pool <- el
+	}
+
+	return pool
+}
+
+func (s *ConcurrentSkipListSet[T]) ForEach(do func(T)) {
+	s.mx.RLock()
+	snapshot := *s.collect(s.data.first(), func(T) bool { return true }).Slice()
+	expected := s.data.mods.Load()
+	s.mx.RUnlock()
+	forEach(snapshot, &s.data.mods, expected, do)
+}
+
+// Iter walks a snapshot in ascending order and panics with
+// collect.ConcurrentModificationError once the set was changed other than
+// through the iterator's Remove.
+func (s *ConcurrentSkipListSet[T]) Iter() collect.Iterator[T] {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return collect.NewIterator(*s.collect(s.data.first(), func(T) bool { return true }).Slice(), func() int {
+		return int(s.data.mods.Load())
+	}, func(_ int, el T) {
+		s.Remove(el)
+	})
+}
+
+func (s *ConcurrentSkipListSet[T]) String() string {
+	var data []string
+	for x := s.data.first(); x != nil; x = s.data.following(x) {
+		data = append(data, fmt.Sprint(x.key))
+	}
+	return "[" + strings.Join(data, " ") + "]"
+}
+
+func (s *ConcurrentSkipListSet[T]) collect(from *skipListNode[T, struct{}], while func(T) bool) *collect.AnyList[T] {
+	var result []T
+	for x := from; x != nil && while(x.key); x = s.data.following(x) {
+		result = append(result, x.key)
+	}
+	return collect.NewAnyList(result...)
+}
+
+func keyOf[K collect.Ordered, V any](n *skipListNode[K, V]) (K, bool) {
+	if n == nil {
+		var zero K
+		return zero, false
+	}
+	return n.key, true
+}
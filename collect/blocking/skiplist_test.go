@@ -0,0 +1,207 @@
+package blocking
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func TestSkipListMap_Ranks(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	m := NewSkipListMap[int, int]()
+	exact := make(map[int]int)
+	for i := 0; i < 5_000; i++ {
+		key := random.Intn(2_000)
+		if random.Intn(3) == 0 {
+			_, ok := m.Remove(key)
+			if _, expected := exact[key]; ok != expected {
+				t.Fatalf("expected error, removed=%t, got=%t", expected, ok)
+			}
+			delete(exact, key)
+		} else {
+			m.Put(key, i)
+			exact[key] = i
+		}
+	}
+
+	var keys []int
+	for key := range exact {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	if m.Size() != len(keys) {
+		t.Fatalf("expected error, size=%d, got=%d", len(keys), m.Size())
+	}
+	for rank, key := range keys {
+		if m.IndexOf(key) != rank {
+			t.Fatalf("expected error, rank=%d, got=%d", rank, m.IndexOf(key))
+		}
+		if entry, ok := m.EntryAt(rank); !ok || entry.Key != key || entry.Value != exact[key] {
+			t.Fatalf("expected error, key=%d, got=%d", key, entry.Key)
+		}
+	}
+	if m.IndexOf(-1) != -1 {
+		t.Errorf("expected error, rank of absent key")
+	}
+}
+
+func TestSkipListMap_Ranges(t *testing.T) {
+	m := NewSkipListMap[string, int]()
+	for i, key := range []string{"d", "b", "a", "e", "c"} {
+		m.Put(key, i)
+	}
+
+	between := m.Between("b", "d")
+	if between.Size() != 2 || between.Get(0).Key != "b" || between.Get(1).Key != "c" {
+		t.Errorf("expected error, incorrect Between %s", between)
+	}
+	if m.From("c").Size() != 3 || m.To("c").Size() != 2 {
+		t.Errorf("expected error, incorrect From/To")
+	}
+
+	first, _ := m.PollFirst()
+	last, _ := m.PollLast()
+	if first.Key != "a" || last.Key != "e" || m.Size() != 3 {
+		t.Errorf("expected error, incorrect polls %v %v", first, last)
+	}
+}
+
+func TestSkipListSet_Concurrent(t *testing.T) {
+	set := NewSkipListSet[int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1_000; j++ {
+				set.Add(i*1_000 + j)
+				set.Contains(j)
+				set.ElementAt(j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if set.Size() != 8_000 {
+		t.Errorf("expected error, size=%d, got=%d", 8_000, set.Size())
+	}
+	if el, _ := set.ElementAt(4_321); el != 4_321 {
+		t.Errorf("expected error, element=%d, got=%d", 4_321, el)
+	}
+	equal := func(a, b int) bool { return a == b }
+	if !set.Between(10, 15).EqualFunc(collect.NewAnyList(10, 11, 12, 13, 14), equal) {
+		t.Errorf("expected error, incorrect Between %s", set.Between(10, 15))
+	}
+}
+
+func TestSkipListSet_IterRemove(t *testing.T) {
+	set := NewSkipListSet(3, 1, 2)
+	var visited []int
+	for it := set.Iter(); it.HasNext(); {
+		el := it.Next()
+		visited = append(visited, el)
+		if el == 2 {
+			it.Remove()
+		}
+	}
+	if len(visited) != 3 || visited[0] != 1 || visited[2] != 3 || set.Contains(2) {
+		t.Errorf("expected error, visited=%v, set=%s", visited, set)
+	}
+
+	set.Add(1)
+	it := set.Iter()
+	set.Add(5)
+	defer func() {
+		if _, ok := recover().(*collect.ConcurrentModificationError); !ok {
+			t.Errorf("expected error, iterator did not panic")
+		}
+	}()
+	it.Next()
+}
+
+func TestSkipListMap_ReadsDoNotLock(t *testing.T) {
+	m := NewSkipListMap[int, string]()
+	m.Put(1, "a")
+	m.Put(2, "b")
+
+	m.mx.Lock()
+	done := make(chan bool)
+	go func() {
+		value, _ := m.Get(2)
+		first, _ := m.First()
+		last, _ := m.Last()
+		done <- value == "b" && first.Key == 1 && last.Key == 2 && m.Between(1, 3).Size() == 2 && m.Size() == 2
+	}()
+	select {
+	case ok := <-done:
+		if !ok {
+			t.Errorf("expected error, incorrect reads %s", m)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("expected error, reads blocked by a writer")
+	}
+	m.mx.Unlock()
+}
+
+func TestSkipListMap_ReadsDuringWrites(t *testing.T) {
+	m := NewSkipListMap[int, int]()
+	for i := 0; i < 1_000; i += 2 {
+		m.Put(i, i)
+	}
+
+	stop := make(chan struct{})
+	var writers sync.WaitGroup
+	for w := 0; w < 2; w++ {
+		writers.Add(1)
+		go func(w int) {
+			defer writers.Done()
+			random := rand.New(rand.NewSource(int64(w)))
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				key := random.Intn(500)*2 + 1
+				m.Put(key, key)
+				m.Remove(key)
+			}
+		}(w)
+	}
+
+	for i := 0; i < 200; i++ {
+		for key := 0; key < 1_000; key += 100 {
+			if value, ok := m.Get(key); !ok || value != key {
+				t.Fatalf("expected error, key=%d, got=%d", key, value)
+			}
+		}
+		previous := -1
+		for _, entry := range *m.From(0).Slice() {
+			if entry.Key <= previous || entry.Key != entry.Value {
+				t.Fatalf("expected error, entry %v after %d", entry, previous)
+			}
+			previous = entry.Key
+		}
+		if first, _ := m.First(); first.Key != 0 {
+			t.Fatalf("expected error, first=%d, got=%d", 0, first.Key)
+		}
+		if last, _ := m.Last(); last.Key < 998 {
+			t.Fatalf("expected error, last=%d, got=%d", 998, last.Key)
+		}
+	}
+	close(stop)
+	writers.Wait()
+
+	if m.Size() != 500 {
+		t.Errorf("expected error, size=%d, got=%d", 500, m.Size())
+	}
+	for rank := 0; rank < 500; rank++ {
+		if entry, _ := m.EntryAt(rank); entry.Key != rank*2 || m.IndexOf(rank*2) != rank {
+			t.Fatalf("expected error, rank=%d, got=%d", rank, m.IndexOf(rank*2))
+		}
+	}
+}
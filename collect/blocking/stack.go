@@ -0,0 +1,173 @@
+package blocking
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+type Stack[T comparable] struct {
+	collectionWithSlice[T]
+	nonEmpty *sync.Cond
+}
+
+func NewStack[T comparable](elements ...T) *Stack[T] {
+	mx := &sync.RWMutex{}
+	return &Stack[T]{
+		collectionWithSlice: collectionWithSlice[T]{
+			mx:   mx,
+			data: &elements,
+		},
+		nonEmpty: sync.NewCond(mx),
+	}
+}
+
+func (s *Stack[T]) Push(element T) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	*s.data = append(*s.data, element)
+	s.mods.Add(1)
+	s.nonEmpty.Broadcast()
+}
+
+func (s *Stack[T]) Add(element T) {
+	s.Push(element)
+}
+
+func (s *Stack[T]) AddAll(elements collect.Collection[T]) {
+	s.AddAllSlice(*collect.NewListOf(elements).Slice())
+}
+
+func (s *Stack[T]) AddAllSlice(elements []T) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	*s.data = append(*s.data, elements...)
+	s.mods.Add(1)
+	s.nonEmpty.Broadcast()
+}
+
+func (s *Stack[T]) RemoveAll(elements collect.Collection[T]) bool {
+	return s.RemoveAllSlice(*collect.NewListOf(elements).Slice())
+}
+
+func (s *Stack[T]) RemoveAllSlice(elements []T) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	modified := false
+	for _, el := range elements {
+		if s.remove(el) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (s *Stack[T]) RemoveIf(predicate func(T) bool) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	kept := (*s.data)[:0]
+	for _, el := range *s.data {
+		if !predicate(el) {
+			kept = append(kept, el)
+		}
+	}
+	modified := len(kept) != len(*s.data)
+	*s.data = kept
+	if modified {
+		s.mods.Add(1)
+	}
+	return modified
+}
+
+// Pop waits until the stack is not empty and removes its top element.
+func (s *Stack[T]) Pop() T {
+	result, _ := s.PopContext(context.Background())
+	return result
+}
+
+func (s *Stack[T]) PopContext(ctx context.Context) (T, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for len(*s.data) == 0 {
+		if err := ctx.Err(); err != nil {
+			var t T
+			return t, err
+		}
+		waitContext(ctx, s.nonEmpty)
+	}
+	return s.pop(), nil
+}
+
+// Pipe pushes every element received from src.
+func (s *Stack[T]) Pipe(ctx context.Context, src <-chan T) error {
+	return pipe(ctx, src, func(_ context.Context, el T) error {
+		s.Push(el)
+		return nil
+	})
+}
+
+// Feed returns a channel of popped elements, newest first, that is closed
+// once ctx is done.
+func (s *Stack[T]) Feed(ctx context.Context) <-chan T {
+	return feed(ctx, restoring(s.PopContext, s.Push))
+}
+
+func (s *Stack[T]) TryPop() (T, bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if len(*s.data) == 0 {
+		var t T
+		return t, false
+	}
+	return s.pop(), true
+}
+
+func (s *Stack[T]) Peek() T {
+	result, ok := s.TryPeek()
+	if !ok {
+		panic("blocking: Peek on empty Stack")
+	}
+	return result
+}
+
+func (s *Stack[T]) TryPeek() (T, bool) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	if len(*s.data) == 0 {
+		var t T
+		return t, false
+	}
+	return (*s.data)[len(*s.data)-1], true
+}
+
+func (s *Stack[T]) Search(element T) int {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	for i := len(*s.data) - 1; i >= 0; i-- {
+		if (*s.data)[i] == element {
+			return len(*s.data) - 1 - i
+		}
+	}
+	return -1
+}
+
+func (s *Stack[T]) remove(element T) bool {
+	for idx, el := range *s.data {
+		if el == element {
+			*s.data = append((*s.data)[:idx], (*s.data)[idx+1:]...)
+			s.mods.Add(1)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Stack[T]) pop() T {
+	var zero T
+	result := (*s.data)[len(*s.data)-1]
+	(*s.data)[len(*s.data)-1] = zero
+	*s.data = (*s.data)[:len(*s.data)-1]
+	s.mods.Add(1)
+	return result
+}
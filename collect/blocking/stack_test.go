@@ -0,0 +1,113 @@
+package blocking
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func TestStack_WaitingPop(t *testing.T) {
+	stack := NewStack[int]()
+	popped := make(chan int)
+	go func() {
+		popped <- stack.Pop()
+	}()
+
+	select {
+	case el := <-popped:
+		t.Fatalf("expected error, Pop returned %d from an empty stack", el)
+	default:
+	}
+	stack.Push(42)
+	select {
+	case el := <-popped:
+		if el != 42 {
+			t.Errorf("expected error, element=%d, got=%d", 42, el)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected error, Pop not woken by Push")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := stack.PopContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
+
+func TestStack_RemoveAll(t *testing.T) {
+	stack := NewStack(1, 2, 3, 2)
+	if !stack.RemoveAll(collect.NewList(2, 4)) || !collect.NewList(1, 3, 2).Equal(collect.NewListOf[int](stack)) {
+		t.Errorf("expected error, stack=%s", stack)
+	}
+	if !stack.RemoveAll(stack) || !stack.IsEmpty() {
+		t.Errorf("expected error, stack=%s", stack)
+	}
+}
+
+func TestStack_RemoveAllSlice(t *testing.T) {
+	stack := NewStack(1, 2, 3)
+	if !stack.RemoveAllSlice([]int{1, 3}) || stack.Size() != 1 || stack.Peek() != 2 {
+		t.Errorf("expected error, stack=%s", stack)
+	}
+	if stack.RemoveAllSlice([]int{5}) {
+		t.Errorf("expected error, nothing to remove %s", stack)
+	}
+}
+
+func TestStack_RemoveIf(t *testing.T) {
+	stack := NewStack(1, 2, 3)
+	removed := stack.RemoveIf(func(el int) bool {
+		return el%2 == 1
+	})
+	if !removed || stack.Size() != 1 || stack.Peek() != 2 {
+		t.Errorf("expected error, stack=%s", stack)
+	}
+}
+
+func TestStack_IterRemove(t *testing.T) {
+	stack := NewStack(1, 2, 3)
+	for it := stack.Iter(); it.HasNext(); {
+		if it.Next() == 2 {
+			it.Remove()
+		}
+	}
+	if stack.Size() != 2 || stack.Contains(2) {
+		t.Errorf("expected error, stack=%s", stack)
+	}
+
+	it := stack.Iter()
+	stack.Push(4)
+	defer func() {
+		if _, ok := recover().(*collect.ConcurrentModificationError); !ok {
+			t.Errorf("expected error, iterator did not panic")
+		}
+	}()
+	it.Next()
+}
+
+func TestStack_PipeFeed(t *testing.T) {
+	stack := NewStack[int]()
+	src := make(chan int, 3)
+	src <- 1
+	src <- 2
+	src <- 3
+	close(src)
+	if err := stack.Pipe(context.Background(), src); err != nil {
+		t.Errorf("expected error, got=%v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	feed := stack.Feed(ctx)
+	for _, expected := range []int{3, 2, 1} {
+		if el := <-feed; el != expected {
+			t.Errorf("expected error, element=%d, got=%d", expected, el)
+		}
+	}
+	cancel()
+	if _, ok := <-feed; ok {
+		t.Errorf("expected error, feed not closed")
+	}
+}
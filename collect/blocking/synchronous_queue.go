@@ -0,0 +1,105 @@
+package blocking
+
+import "context"
+
+// handoff carries an element from Put to a consumer. The consumer answers on
+// accepted exactly once; a Feed that could not deliver the element answers
+// false, and Put offers it again.
+type handoff[T any] struct {
+	element   T
+	accepted  chan bool
+	withdrawn chan struct{}
+}
+
+// SynchronousQueue has no capacity: every Put waits for a matching Take and
+// the other way round. It does not implement collect.Queue, since it never
+// holds an element to Peek at.
+type SynchronousQueue[T any] struct {
+	handoff chan *handoff[T]
+}
+
+func NewSynchronousQueue[T any]() *SynchronousQueue[T] {
+	return &SynchronousQueue[T]{handoff: make(chan *handoff[T])}
+}
+
+func (q *SynchronousQueue[T]) Put(ctx context.Context, element T) error {
+	for {
+		h := newHandoff(element)
+		select {
+		case q.handoff <- h:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		select {
+		case ok := <-h.accepted:
+			if ok {
+				return nil
+			}
+		case <-ctx.Done():
+			close(h.withdrawn)
+			if <-h.accepted {
+				return nil
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+func (q *SynchronousQueue[T]) Take(ctx context.Context) (T, error) {
+	select {
+	case h := <-q.handoff:
+		h.accepted <- true
+		return h.element, nil
+	case <-ctx.Done():
+		var t T
+		return t, ctx.Err()
+	}
+}
+
+// TryOffer hands element over only if a consumer is already waiting in Take
+// or Feed. A Feed consumer has it only once its receiver got the element.
+func (q *SynchronousQueue[T]) TryOffer(element T) bool {
+	h := newHandoff(element)
+	select {
+	case q.handoff <- h:
+		return <-h.accepted
+	default:
+		return false
+	}
+}
+
+// TryPool takes an element only if a producer is already waiting in Put.
+func (q *SynchronousQueue[T]) TryPool() (T, bool) {
+	select {
+	case h := <-q.handoff:
+		h.accepted <- true
+		return h.element, true
+	default:
+		var t T
+		return t, false
+	}
+}
+
+// Pipe puts every element received from src, waiting for a consumer each time.
+func (q *SynchronousQueue[T]) Pipe(ctx context.Context, src <-chan T) error {
+	return pipe(ctx, src, q.Put)
+}
+
+// Feed returns a channel of taken elements that is closed once ctx is done.
+// The producer's Put only returns once the receiver got its element.
+func (q *SynchronousQueue[T]) Feed(ctx context.Context) <-chan T {
+	return feed(ctx, func(ctx context.Context) (feedItem[T], error) {
+		select {
+		case h := <-q.handoff:
+			return feedItem[T]{element: h.element, withdrawn: h.withdrawn, settle: func(delivered bool) {
+				h.accepted <- delivered
+			}}, nil
+		case <-ctx.Done():
+			return feedItem[T]{}, ctx.Err()
+		}
+	})
+}
+
+func newHandoff[T any](element T) *handoff[T] {
+	return &handoff[T]{element: element, accepted: make(chan bool, 1), withdrawn: make(chan struct{})}
+}
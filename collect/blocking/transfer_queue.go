@@ -0,0 +1,224 @@
+package blocking
+
+import (
+	"context"
+	"sync"
+)
+
+// transferNode is an element in the queue. Nodes enqueued by Transfer have a
+// delivered channel, closed once a consumer received the element, and a
+// withdrawn channel, closed by a cancelled Transfer while a Feed holds the
+// node; the Feed then closes dropped instead of putting the node back.
+type transferNode[T any] struct {
+	element   T
+	delivered chan struct{}
+	withdrawn chan struct{}
+	dropped   chan struct{}
+}
+
+// LinkedTransferQueue is an unbounded FIFO queue whose producers may either
+// Offer without waiting or Transfer and wait until a consumer receives the
+// element.
+type LinkedTransferQueue[T any] struct {
+	mx       *sync.Mutex
+	data     []*transferNode[T]
+	nonEmpty *sync.Cond
+	waiting  int
+}
+
+func NewTransferQueue[T any](elements ...T) *LinkedTransferQueue[T] {
+	mx := &sync.Mutex{}
+	q := &LinkedTransferQueue[T]{
+		mx:       mx,
+		nonEmpty: sync.NewCond(mx),
+	}
+	for _, el := range elements {
+		q.data = append(q.data, &transferNode[T]{element: el})
+	}
+	return q
+}
+
+func (q *LinkedTransferQueue[T]) Offer(element T) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	q.data = append(q.data, &transferNode[T]{element: element})
+	q.nonEmpty.Signal()
+}
+
+// Transfer enqueues element and waits until a consumer has received it. If ctx
+// is done first, the element is withdrawn unless it was already received.
+func (q *LinkedTransferQueue[T]) Transfer(ctx context.Context, element T) error {
+	node := &transferNode[T]{
+		element:   element,
+		delivered: make(chan struct{}),
+		withdrawn: make(chan struct{}),
+		dropped:   make(chan struct{}),
+	}
+	q.mx.Lock()
+	q.data = append(q.data, node)
+	q.nonEmpty.Signal()
+	q.mx.Unlock()
+
+	select {
+	case <-node.delivered:
+		return nil
+	case <-ctx.Done():
+		q.mx.Lock()
+		for i, n := range q.data {
+			if n == node {
+				q.data = append(q.data[:i], q.data[i+1:]...)
+				q.mx.Unlock()
+				return ctx.Err()
+			}
+		}
+		close(node.withdrawn)
+		q.mx.Unlock()
+
+		select {
+		case <-node.delivered:
+			return nil
+		case <-node.dropped:
+			return ctx.Err()
+		}
+	}
+}
+
+// TryTransfer enqueues element only if a waiting consumer is free to take it.
+func (q *LinkedTransferQueue[T]) TryTransfer(element T) bool {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	if q.waiting <= len(q.data) {
+		return false
+	}
+	q.data = append(q.data, &transferNode[T]{element: element})
+	q.nonEmpty.Signal()
+	return true
+}
+
+func (q *LinkedTransferQueue[T]) Take(ctx context.Context) (T, error) {
+	node, err := q.take(ctx)
+	if err != nil {
+		var t T
+		return t, err
+	}
+	q.deliver(node)
+	return node.element, nil
+}
+
+// Pool waits until an element is available and removes it.
+func (q *LinkedTransferQueue[T]) Pool() T {
+	result, _ := q.Take(context.Background())
+	return result
+}
+
+func (q *LinkedTransferQueue[T]) TryPool() (T, bool) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	if len(q.data) == 0 {
+		var t T
+		return t, false
+	}
+	return q.pop(), true
+}
+
+// Peek returns the head of the queue, or the zero value if it is empty.
+func (q *LinkedTransferQueue[T]) Peek() T {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	if len(q.data) == 0 {
+		var t T
+		return t
+	}
+	return q.data[0].element
+}
+
+func (q *LinkedTransferQueue[T]) WaitingConsumers() int {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	return q.waiting
+}
+
+func (q *LinkedTransferQueue[T]) Size() int {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	return len(q.data)
+}
+
+func (q *LinkedTransferQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+func (q *LinkedTransferQueue[T]) pop() T {
+	node := q.data[0]
+	q.data[0] = nil
+	q.data = q.data[1:]
+	if node.delivered != nil {
+		close(node.delivered)
+	}
+	return node.element
+}
+
+// Pipe transfers every element received from src, so the next one is not read
+// until a consumer has received the previous.
+func (q *LinkedTransferQueue[T]) Pipe(ctx context.Context, src <-chan T) error {
+	return pipe(ctx, src, q.Transfer)
+}
+
+// Feed returns a channel of taken elements that is closed once ctx is done.
+// A Transfer only returns once the receiver got its element.
+func (q *LinkedTransferQueue[T]) Feed(ctx context.Context) <-chan T {
+	return feed(ctx, func(ctx context.Context) (feedItem[T], error) {
+		node, err := q.take(ctx)
+		if err != nil {
+			return feedItem[T]{}, err
+		}
+		return feedItem[T]{element: node.element, withdrawn: node.withdrawn, settle: func(delivered bool) {
+			if delivered {
+				q.deliver(node)
+			} else {
+				q.restore(node)
+			}
+		}}, nil
+	})
+}
+
+// take waits for the head node and removes it without delivering it.
+func (q *LinkedTransferQueue[T]) take(ctx context.Context) (*transferNode[T], error) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	q.waiting++
+	defer func() { q.waiting-- }()
+	for len(q.data) == 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		waitContext(ctx, q.nonEmpty)
+	}
+	node := q.data[0]
+	q.data[0] = nil
+	q.data = q.data[1:]
+	return node, nil
+}
+
+func (q *LinkedTransferQueue[T]) deliver(node *transferNode[T]) {
+	if node.delivered != nil {
+		close(node.delivered)
+	}
+}
+
+// restore puts back a node a Feed could not deliver, unless its Transfer was
+// cancelled in the meantime. Both sides decide under the lock.
+func (q *LinkedTransferQueue[T]) restore(node *transferNode[T]) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	if node.withdrawn != nil {
+		select {
+		case <-node.withdrawn:
+			close(node.dropped)
+			return
+		default:
+		}
+	}
+	q.data = append([]*transferNode[T]{node}, q.data...)
+	q.nonEmpty.Signal()
+}
@@ -0,0 +1,78 @@
+package blocking
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSynchronousQueue_Handoff(t *testing.T) {
+	queue := NewSynchronousQueue[int]()
+	if queue.TryOffer(1) {
+		t.Errorf("expected error, offer accepted without consumer")
+	}
+
+	go func() {
+		_ = queue.Put(context.Background(), 42)
+	}()
+	el, err := queue.Take(context.Background())
+	if err != nil || el != 42 {
+		t.Errorf("expected error, element=%d, got=%d (%v)", 42, el, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := queue.Put(ctx, 1); err != context.DeadlineExceeded {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
+
+func TestLinkedTransferQueue_Transfer(t *testing.T) {
+	queue := NewTransferQueue[int]()
+	transferred := make(chan error)
+	go func() {
+		transferred <- queue.Transfer(context.Background(), 42)
+	}()
+
+	select {
+	case <-transferred:
+		t.Fatal("expected error, Transfer returned before Take")
+	case <-time.After(10 * time.Millisecond):
+	}
+	if el, err := queue.Take(context.Background()); err != nil || el != 42 {
+		t.Errorf("expected error, element=%d, got=%d (%v)", 42, el, err)
+	}
+	if err := <-transferred; err != nil {
+		t.Errorf("expected error, got=%v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := queue.Transfer(ctx, 1); err != context.DeadlineExceeded || !queue.IsEmpty() {
+		t.Errorf("expected error, element not withdrawn, got=%v", err)
+	}
+
+	queue.Offer(7)
+	if queue.TryTransfer(8) || queue.Peek() != 7 || queue.Pool() != 7 {
+		t.Errorf("expected error, incorrect queue state")
+	}
+}
+
+func TestExchanger_Exchange(t *testing.T) {
+	exchanger := NewExchanger[string]()
+	other := make(chan string)
+	go func() {
+		got, _ := exchanger.Exchange(context.Background(), "left")
+		other <- got
+	}()
+	got, err := exchanger.Exchange(context.Background(), "right")
+	if err != nil || got != "left" || <-other != "right" {
+		t.Errorf("expected error, values not swapped, got=%s", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := exchanger.Exchange(ctx, "alone"); err != context.DeadlineExceeded {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
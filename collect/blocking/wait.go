@@ -0,0 +1,28 @@
+package blocking
+
+import (
+	"context"
+	"sync"
+)
+
+// waitContext waits on cond like cond.Wait, but also wakes up when ctx is done.
+// The caller must hold cond.L and re-check ctx.Err after it returns.
+func waitContext(ctx context.Context, cond *sync.Cond) {
+	if ctx.Done() == nil {
+		cond.Wait()
+		return
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			cond.L.Lock()
+			cond.Broadcast()
+			cond.L.Unlock()
+		case <-stop:
+		}
+	}()
+	cond.Wait()
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Put(key K, value V)
+	Peek(key K) (V, bool)
+	Remove(key K) bool
+	Len() int
+	Capacity() int
+	Stats() Stats
+	Clear()
+}
+
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type blockingCache[K comparable, V any] struct {
+	mx   *sync.RWMutex
+	data Cache[K, V]
+}
+
+// Blocking guards c with a lock. Get takes the write lock because a hit
+// reorders the underlying eviction state.
+func Blocking[K comparable, V any](c Cache[K, V]) Cache[K, V] {
+	return &blockingCache[K, V]{
+		mx:   &sync.RWMutex{},
+		data: c,
+	}
+}
+
+func (c *blockingCache[K, V]) Get(key K) (V, bool) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.data.Get(key)
+}
+
+func (c *blockingCache[K, V]) Put(key K, value V) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.data.Put(key, value)
+}
+
+func (c *blockingCache[K, V]) Peek(key K) (V, bool) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	return c.data.Peek(key)
+}
+
+func (c *blockingCache[K, V]) Remove(key K) bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.data.Remove(key)
+}
+
+func (c *blockingCache[K, V]) Len() int {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	return c.data.Len()
+}
+
+func (c *blockingCache[K, V]) Capacity() int {
+	return c.data.Capacity()
+}
+
+func (c *blockingCache[K, V]) Stats() Stats {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	return c.data.Stats()
+}
+
+func (c *blockingCache[K, V]) Clear() {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.data.Clear()
+}
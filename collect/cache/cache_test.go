@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestLRUCache(t *testing.T) {
+	var evicted []string
+	c := NewLRU(2, func(key string, _ int) {
+		evicted = append(evicted, key)
+	})
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	c.Put("c", 3)
+
+	if _, ok := c.Peek("b"); ok {
+		t.Errorf("expected error, least recently used key not evicted")
+	}
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("expected error, evicted=%v", evicted)
+	}
+	c.Get("b")
+	if stats := c.Stats(); stats.Hits != 1 || stats.Misses != 1 || stats.Evictions != 1 {
+		t.Errorf("expected error, incorrect stats %+v", stats)
+	}
+}
+
+func TestLFUCache(t *testing.T) {
+	c := NewLFU[string, int](2, nil)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	c.Put("c", 3)
+
+	if _, ok := c.Peek("b"); ok {
+		t.Errorf("expected error, least frequently used key not evicted")
+	}
+	if c.Frequency("a") != 3 || c.Frequency("c") != 1 {
+		t.Errorf("expected error, frequency=%d, got=%d", 3, c.Frequency("a"))
+	}
+
+	c.Remove("c")
+	c.Put("d", 4)
+	c.Put("e", 5)
+	if _, ok := c.Peek("a"); !ok || c.Len() != 2 {
+		t.Errorf("expected error, most frequently used key evicted")
+	}
+}
+
+func TestTTLCache(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	evicted := 0
+	c := NewTTLWithClock(10, time.Minute, func(string, int) {
+		evicted++
+	}, clock)
+	c.Put("a", 1)
+	clock.now = clock.now.Add(30 * time.Second)
+	c.Put("b", 2)
+
+	clock.now = clock.now.Add(31 * time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("expected error, expired key returned")
+	}
+	if c.Len() != 1 || evicted != 1 {
+		t.Errorf("expected error, len=%d, got=%d", 1, c.Len())
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	if c.Len() != 0 {
+		t.Errorf("expected error, len=%d, got=%d", 0, c.Len())
+	}
+	c.Purge()
+	if evicted != 2 {
+		t.Errorf("expected error, evicted=%d, got=%d", 2, evicted)
+	}
+}
+
+func TestBlocking(t *testing.T) {
+	c := Blocking[int, int](NewLRU[int, int](100, nil))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				c.Put(j, i)
+				c.Get(j - 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if c.Len() != 100 {
+		t.Errorf("expected error, len=%d, got=%d", 100, c.Len())
+	}
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+)
+
+type lfuEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	frequency int
+}
+
+type LFUCache[K comparable, V any] struct {
+	capacity     int
+	items        map[K]*list.Element
+	frequencies  map[int]*list.List
+	minFrequency int
+	onEvict      func(key K, value V)
+	stats        Stats
+}
+
+func NewLFU[K comparable, V any](capacity int, onEvict func(key K, value V)) *LFUCache[K, V] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("cache: non-positive capacity %d", capacity))
+	}
+	return &LFUCache[K, V]{
+		capacity:    capacity,
+		items:       make(map[K]*list.Element),
+		frequencies: make(map[int]*list.List),
+		onEvict:     onEvict,
+	}
+}
+
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		var v V
+		return v, false
+	}
+	c.stats.Hits++
+	c.touch(el)
+	return el.Value.(*lfuEntry[K, V]).value, true
+}
+
+func (c *LFUCache[K, V]) Put(key K, value V) {
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lfuEntry[K, V]).value = value
+		c.touch(el)
+		return
+	}
+	if len(c.items) >= c.capacity {
+		c.evict()
+	}
+	c.minFrequency = 1
+	c.items[key] = c.bucket(1).PushFront(&lfuEntry[K, V]{key: key, value: value, frequency: 1})
+}
+
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		var v V
+		return v, false
+	}
+	return el.Value.(*lfuEntry[K, V]).value, true
+}
+
+func (c *LFUCache[K, V]) Remove(key K) bool {
+	el, ok := c.items[key]
+	if ok {
+		c.unlink(el)
+		delete(c.items, key)
+	}
+	return ok
+}
+
+func (c *LFUCache[K, V]) Frequency(key K) int {
+	el, ok := c.items[key]
+	if !ok {
+		return 0
+	}
+	return el.Value.(*lfuEntry[K, V]).frequency
+}
+
+func (c *LFUCache[K, V]) Len() int {
+	return len(c.items)
+}
+
+func (c *LFUCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *LFUCache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+func (c *LFUCache[K, V]) Clear() {
+	c.items = make(map[K]*list.Element)
+	c.frequencies = make(map[int]*list.List)
+	c.minFrequency = 0
+}
+
+func (c *LFUCache[K, V]) touch(el *list.Element) {
+	e := c.unlink(el)
+	if e.frequency == c.minFrequency && c.frequencies[e.frequency] == nil {
+		c.minFrequency++
+	}
+	e.frequency++
+	c.items[e.key] = c.bucket(e.frequency).PushFront(e)
+}
+
+func (c *LFUCache[K, V]) evict() {
+	bucket, ok := c.frequencies[c.minFrequency]
+	if !ok {
+		// Remove may have emptied the least frequent bucket.
+		for frequency, candidate := range c.frequencies {
+			if bucket == nil || frequency < c.minFrequency {
+				c.minFrequency, bucket = frequency, candidate
+			}
+		}
+	}
+	e := c.unlink(bucket.Back())
+	delete(c.items, e.key)
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+func (c *LFUCache[K, V]) unlink(el *list.Element) *lfuEntry[K, V] {
+	e := el.Value.(*lfuEntry[K, V])
+	bucket := c.frequencies[e.frequency]
+	bucket.Remove(el)
+	if bucket.Len() == 0 {
+		delete(c.frequencies, e.frequency)
+	}
+	return e
+}
+
+func (c *LFUCache[K, V]) bucket(frequency int) *list.List {
+	bucket, ok := c.frequencies[frequency]
+	if !ok {
+		bucket = list.New()
+		c.frequencies[frequency] = bucket
+	}
+	return bucket
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+)
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+type LRUCache[K comparable, V any] struct {
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List
+	onEvict  func(key K, value V)
+	stats    Stats
+}
+
+func NewLRU[K comparable, V any](capacity int, onEvict func(key K, value V)) *LRUCache[K, V] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("cache: non-positive capacity %d", capacity))
+	}
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+		onEvict:  onEvict,
+	}
+}
+
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		var v V
+		return v, false
+	}
+	c.stats.Hits++
+	c.order.MoveToFront(el)
+	return el.Value.(*entry[K, V]).value, true
+}
+
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	if el, ok := c.items[key]; ok {
+		el.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	if c.order.Len() >= c.capacity {
+		c.evict(c.order.Back())
+	}
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+}
+
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		var v V
+		return v, false
+	}
+	return el.Value.(*entry[K, V]).value, true
+}
+
+func (c *LRUCache[K, V]) Remove(key K) bool {
+	el, ok := c.items[key]
+	if ok {
+		c.order.Remove(el)
+		delete(c.items, key)
+	}
+	return ok
+}
+
+func (c *LRUCache[K, V]) Len() int {
+	return c.order.Len()
+}
+
+func (c *LRUCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *LRUCache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+func (c *LRUCache[K, V]) Clear() {
+	c.items = make(map[K]*list.Element)
+	c.order.Init()
+}
+
+func (c *LRUCache[K, V]) evict(el *list.Element) {
+	e := c.order.Remove(el).(*entry[K, V])
+	delete(c.items, e.key)
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
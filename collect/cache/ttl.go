@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"time"
+)
+
+type ttlEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+type TTLCache[K comparable, V any] struct {
+	capacity int
+	ttl      time.Duration
+	clock    Clock
+	items    map[K]*list.Element
+	order    *list.List
+	onEvict  func(key K, value V)
+	stats    Stats
+}
+
+func NewTTL[K comparable, V any](capacity int, ttl time.Duration, onEvict func(key K, value V)) *TTLCache[K, V] {
+	return NewTTLWithClock(capacity, ttl, onEvict, SystemClock())
+}
+
+func NewTTLWithClock[K comparable, V any](capacity int, ttl time.Duration, onEvict func(key K, value V), clock Clock) *TTLCache[K, V] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("cache: non-positive capacity %d", capacity))
+	}
+	if ttl <= 0 {
+		panic(fmt.Sprintf("cache: non-positive ttl %s", ttl))
+	}
+	return &TTLCache[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		clock:    clock,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+		onEvict:  onEvict,
+	}
+}
+
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		var v V
+		return v, false
+	}
+	e := el.Value.(*ttlEntry[K, V])
+	if !c.clock.Now().Before(e.expires) {
+		c.stats.Misses++
+		c.evict(el)
+		var v V
+		return v, false
+	}
+	c.stats.Hits++
+	return e.value, true
+}
+
+func (c *TTLCache[K, V]) Put(key K, value V) {
+	now := c.clock.Now()
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*ttlEntry[K, V])
+		e.value = value
+		e.expires = now.Add(c.ttl)
+		c.order.MoveToBack(el)
+		return
+	}
+	c.purge(now)
+	if c.order.Len() >= c.capacity {
+		c.evict(c.order.Front())
+	}
+	c.items[key] = c.order.PushBack(&ttlEntry[K, V]{key: key, value: value, expires: now.Add(c.ttl)})
+}
+
+func (c *TTLCache[K, V]) Peek(key K) (V, bool) {
+	el, ok := c.items[key]
+	if !ok || !c.clock.Now().Before(el.Value.(*ttlEntry[K, V]).expires) {
+		var v V
+		return v, false
+	}
+	return el.Value.(*ttlEntry[K, V]).value, true
+}
+
+func (c *TTLCache[K, V]) Remove(key K) bool {
+	el, ok := c.items[key]
+	if ok {
+		c.order.Remove(el)
+		delete(c.items, key)
+	}
+	return ok
+}
+
+func (c *TTLCache[K, V]) Purge() {
+	c.purge(c.clock.Now())
+}
+
+// Len counts only live entries. Entries are kept in expiry order, so the
+// expired ones are always at the front of the list.
+func (c *TTLCache[K, V]) Len() int {
+	now := c.clock.Now()
+	expired := 0
+	for el := c.order.Front(); el != nil && !now.Before(el.Value.(*ttlEntry[K, V]).expires); el = el.Next() {
+		expired++
+	}
+	return c.order.Len() - expired
+}
+
+func (c *TTLCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *TTLCache[K, V]) TTL() time.Duration {
+	return c.ttl
+}
+
+func (c *TTLCache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+func (c *TTLCache[K, V]) Clear() {
+	c.items = make(map[K]*list.Element)
+	c.order.Init()
+}
+
+func (c *TTLCache[K, V]) purge(now time.Time) {
+	for el := c.order.Front(); el != nil && !now.Before(el.Value.(*ttlEntry[K, V]).expires); el = c.order.Front() {
+		c.evict(el)
+	}
+}
+
+func (c *TTLCache[K, V]) evict(el *list.Element) {
+	e := c.order.Remove(el).(*ttlEntry[K, V])
+	delete(c.items, e.key)
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
@@ -0,0 +1,28 @@
+package collect
+
+import "context"
+
+// FromChan collects the elements received from ch until it is closed or ctx
+// is done.
+func FromChan[T comparable](ctx context.Context, ch <-chan T) *ArrayList[T] {
+	result := NewList[T]()
+	_ = CollectInto[T](ctx, ch, result)
+	return result
+}
+
+// CollectInto adds the elements received from ch to collection until ch is
+// closed, in which case it returns nil, or ctx is done, in which case it
+// returns ctx.Err().
+func CollectInto[T any](ctx context.Context, ch <-chan T, collection Collection[T]) error {
+	for {
+		select {
+		case el, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			collection.Add(el)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
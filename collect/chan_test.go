@@ -0,0 +1,29 @@
+package collect
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	if list := FromChan(context.Background(), ch); !list.Equal(NewList(1, 2, 3)) {
+		t.Errorf("expected error, list=%s", list)
+	}
+}
+
+func TestCollectInto(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 1
+	set := NewSet[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := CollectInto[int](ctx, ch, set); err != context.DeadlineExceeded || !set.Equal(NewSet(1)) {
+		t.Errorf("expected error, set=%s, got=%v", set, err)
+	}
+}
@@ -1,8 +1,11 @@
 package collect
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+)
 
-type Collection[T comparable] interface {
+type Collection[T any] interface {
 	Add(element T)
 	AddAll(elements Collection[T])
 	AddAllSlice(elements []T)
@@ -25,106 +28,103 @@ type Collection[T comparable] interface {
 	String() string
 }
 
+// collectionWithSlice is the comparable API over anySlice, comparing elements
+// with ==.
 type collectionWithSlice[T comparable] struct {
-	data *[]T
+	sliceCollection[T]
 }
 
-func (c *collectionWithSlice[T]) Add(element T) {
-	*c.data = append(*c.data, element)
+func newCollectionWithSlice[T comparable](data *[]T) collectionWithSlice[T] {
+	return collectionWithSlice[T]{sliceCollection[T]{anySlice: &anySlice[T]{data: data}, indexOf: indexOf[T]}}
 }
 
-func (c *collectionWithSlice[T]) AddAll(elements Collection[T]) {
-	for el := range elements.Iterator() {
-		c.Add(el)
+func indexOf[T comparable](data []T, element T) int {
+	for idx, el := range data {
+		if el == element {
+			return idx
+		}
 	}
+	return -1
 }
 
-func (c *collectionWithSlice[T]) AddAllSlice(elements []T) {
-	for _, el := range elements {
-		c.Add(el)
-	}
+func deepEqual[T any](a, b T) bool {
+	return reflect.DeepEqual(a, b)
 }
 
-func (c *collectionWithSlice[T]) Contains(element T) bool {
-	for _, el := range *c.data {
-		if el == element {
-			return true
-		}
-	}
-	return false
+// anySlice holds the data of a slice-backed collection. Views of the
+// collection point to the same anySlice, so they share one modification
+// counter and one copy-on-write flag.
+type anySlice[T any] struct {
+	data   *[]T
+	mods   int
+	shared bool
 }
 
-func (c *collectionWithSlice[T]) ContainsAll(elements Collection[T]) bool {
+func (c *anySlice[T]) Add(element T) {
+	c.own()
+	*c.data = append(*c.data, element)
+	c.mods++
+}
+
+func (c *anySlice[T]) AddAll(elements Collection[T]) {
 	for el := range elements.Iterator() {
-		if !c.Contains(el) {
-			return false
-		}
+		c.Add(el)
 	}
-	return true
 }
 
-func (c *collectionWithSlice[T]) ContainsAllSlice(elements []T) bool {
+func (c *anySlice[T]) AddAllSlice(elements []T) {
 	for _, el := range elements {
-		if !c.Contains(el) {
-			return false
-		}
+		c.Add(el)
 	}
-	return true
 }
 
-func (c *collectionWithSlice[T]) Remove(element T) bool {
-	for idx, el := range *c.data {
-		if el == element {
-			*c.data = append((*c.data)[:idx], (*c.data)[idx+1:]...)
-			return true
-		}
-	}
-	return false
+func (c *anySlice[T]) ContainsFunc(element T, equal func(a, b T) bool) bool {
+	return c.IndexOfFunc(element, equal) != -1
 }
 
-func (c *collectionWithSlice[T]) RemoveAll(elements Collection[T]) bool {
-	modified := false
-	for el := range elements.Iterator() {
-		if c.Remove(el) {
-			modified = true
+func (c *anySlice[T]) IndexOfFunc(element T, equal func(a, b T) bool) int {
+	for idx, el := range *c.data {
+		if equal(el, element) {
+			return idx
 		}
 	}
-	return modified
+	return -1
 }
 
-func (c *collectionWithSlice[T]) RemoveAllSlice(elements []T) bool {
-	modified := false
-	for _, el := range elements {
-		if c.Remove(el) {
-			modified = true
-		}
-	}
-	return modified
+func (c *anySlice[T]) RemoveFunc(element T, equal func(a, b T) bool) bool {
+	return c.removeAt(c.IndexOfFunc(element, equal))
 }
 
-func (c *collectionWithSlice[T]) RemoveIf(predicate func(T) bool) bool {
-	modified := false
-	for el := range c.Iterator() {
-		if predicate(el) && c.Remove(el) {
-			modified = true
+func (c *anySlice[T]) RemoveIf(predicate func(T) bool) bool {
+	c.own()
+	kept := (*c.data)[:0]
+	for _, el := range *c.data {
+		if !predicate(el) {
+			kept = append(kept, el)
 		}
 	}
+	modified := len(kept) != len(*c.data)
+	if modified {
+		*c.data = kept
+		c.mods++
+	}
 	return modified
 }
 
-func (c *collectionWithSlice[T]) Size() int {
+func (c *anySlice[T]) Size() int {
 	return len(*c.data)
 }
 
-func (c *collectionWithSlice[T]) IsEmpty() bool {
+func (c *anySlice[T]) IsEmpty() bool {
 	return c.Size() == 0
 }
 
-func (c *collectionWithSlice[T]) Clear() {
+func (c *anySlice[T]) Clear() {
 	*c.data = nil
+	c.mods++
 }
 
-func (c *collectionWithSlice[T]) Iterator() <-chan T {
+func (c *anySlice[T]) Iterator() <-chan T {
 	pool := make(chan T, len(*c.data))
 	defer close(pool)
 
@@ -135,12 +135,109 @@ func (c *collectionWithSlice[T]) Iterator() <-chan T {
 	return pool
 }
 
-func (c *collectionWithSlice[T]) ForEach(do func(T)) {
+func (c *anySlice[T]) ForEach(do func(T)) {
+	mods := c.mods
 	for _, val := range *c.data {
 		do(val)
+		checkModifications(mods, c.mods)
+	}
+}
+
+func (c *anySlice[T]) Iter() Iterator[T] {
+	return newSliceIterator(c.data, &c.mods)
+}
+
+// own copies the data before it is written in place if a snapshot still
+// shares it.
+func (c *anySlice[T]) own() {
+	if c.shared {
+		*c.data = append([]T(nil), *c.data...)
+		c.shared = false
 	}
 }
 
-func (c *collectionWithSlice[T]) String() string {
+func (c *anySlice[T]) String() string {
 	return fmt.Sprint(*c.data)
 }
+
+func (c *anySlice[T]) removeAt(idx int) bool {
+	if idx == -1 {
+		return false
+	}
+	c.own()
+	*c.data = append((*c.data)[:idx], (*c.data)[idx+1:]...)
+	c.mods++
+	return true
+}
+
+// sliceCollection adds the equality-based operations to anySlice. It searches
+// through indexOf rather than an equality func so that the comparable types
+// keep a plain == loop.
+type sliceCollection[T any] struct {
+	*anySlice[T]
+	indexOf func(data []T, element T) int
+}
+
+func newSliceCollection[T any](data *[]T, equal func(a, b T) bool) sliceCollection[T] {
+	return sliceCollection[T]{
+		anySlice: &anySlice[T]{data: data},
+		indexOf: func(data []T, element T) int {
+			for idx, el := range data {
+				if equal(el, element) {
+					return idx
+				}
+			}
+			return -1
+		},
+	}
+}
+
+func (c *sliceCollection[T]) Contains(element T) bool {
+	return c.IndexOf(element) != -1
+}
+
+func (c *sliceCollection[T]) ContainsAll(elements Collection[T]) bool {
+	for el := range elements.Iterator() {
+		if !c.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *sliceCollection[T]) ContainsAllSlice(elements []T) bool {
+	for _, el := range elements {
+		if !c.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *sliceCollection[T]) IndexOf(element T) int {
+	return c.indexOf(*c.data, element)
+}
+
+func (c *sliceCollection[T]) Remove(element T) bool {
+	return c.removeAt(c.IndexOf(element))
+}
+
+func (c *sliceCollection[T]) RemoveAll(elements Collection[T]) bool {
+	modified := false
+	for el := range elements.Iterator() {
+		if c.Remove(el) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (c *sliceCollection[T]) RemoveAllSlice(elements []T) bool {
+	modified := false
+	for _, el := range elements {
+		if c.Remove(el) {
+			modified = true
+		}
+	}
+	return modified
+}
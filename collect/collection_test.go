@@ -246,11 +246,11 @@ func generateRandomSlice(count int) []int {
 
 func createCollectionOf(size int) *collectionWithSlice[int] {
 	var arr []int
-	coll := &collectionWithSlice[int]{&arr}
+	coll := newCollectionWithSlice(&arr)
 	for i := 0; i < size; i++ {
 		coll.Add(i)
 	}
-	return coll
+	return &coll
 }
 
 func checkCollectionOf(coll *collectionWithSlice[int], counts []int) error {
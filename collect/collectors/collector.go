@@ -0,0 +1,152 @@
+package collectors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+// Collector describes a reduction of elements into a result. Each call starts
+// a fresh accumulation and returns the function adding an element and the
+// function producing the result, so collectors can be reused and nested as
+// downstream collectors.
+type Collector[T any, R any] func() (accumulate func(T), finish func() R)
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Optional is the result of MinBy and MaxBy, empty when no element was seen.
+type Optional[T any] struct {
+	Value   T
+	Present bool
+}
+
+func Collect[T any, R any](collection collect.Collection[T], collector Collector[T, R]) R {
+	accumulate, finish := collector()
+	for el := range collection.Iterator() {
+		accumulate(el)
+	}
+	return finish()
+}
+
+func ToList[T comparable]() Collector[T, *collect.ArrayList[T]] {
+	return func() (func(T), func() *collect.ArrayList[T]) {
+		list := collect.NewList[T]()
+		return list.Add, func() *collect.ArrayList[T] {
+			return list
+		}
+	}
+}
+
+func ToSet[T comparable]() Collector[T, *collect.HashSet[T]] {
+	return func() (func(T), func() *collect.HashSet[T]) {
+		set := collect.NewSet[T]()
+		return set.Add, func() *collect.HashSet[T] {
+			return set
+		}
+	}
+}
+
+// Mapping applies fn to every element before passing it to downstream.
+func Mapping[T any, U any, R any](fn func(T) U, downstream Collector[U, R]) Collector[T, R] {
+	return func() (func(T), func() R) {
+		accumulate, finish := downstream()
+		return func(el T) {
+			accumulate(fn(el))
+		}, finish
+	}
+}
+
+// Filtering passes only the elements matching predicate to downstream.
+func Filtering[T any, R any](predicate func(T) bool, downstream Collector[T, R]) Collector[T, R] {
+	return func() (func(T), func() R) {
+		accumulate, finish := downstream()
+		return func(el T) {
+			if predicate(el) {
+				accumulate(el)
+			}
+		}, finish
+	}
+}
+
+func Reducing[T any](identity T, op func(T, T) T) Collector[T, T] {
+	return func() (func(T), func() T) {
+		result := identity
+		return func(el T) {
+				result = op(result, el)
+			}, func() T {
+				return result
+			}
+	}
+}
+
+func Counting[T any]() Collector[T, int] {
+	return func() (func(T), func() int) {
+		count := 0
+		return func(T) {
+				count++
+			}, func() int {
+				return count
+			}
+	}
+}
+
+func Summing[T Number]() Collector[T, T] {
+	return Reducing(0, func(a, b T) T {
+		return a + b
+	})
+}
+
+// Averaging returns the arithmetic mean, or 0 for no elements.
+func Averaging[T Number]() Collector[T, float64] {
+	return func() (func(T), func() float64) {
+		sum, count := 0.0, 0
+		return func(el T) {
+				sum += float64(el)
+				count++
+			}, func() float64 {
+				if count == 0 {
+					return 0
+				}
+				return sum / float64(count)
+			}
+	}
+}
+
+// MinBy keeps the first of the smallest elements according to less.
+func MinBy[T any](less func(a, b T) bool) Collector[T, Optional[T]] {
+	return func() (func(T), func() Optional[T]) {
+		var result Optional[T]
+		return func(el T) {
+				if !result.Present || less(el, result.Value) {
+					result = Optional[T]{Value: el, Present: true}
+				}
+			}, func() Optional[T] {
+				return result
+			}
+	}
+}
+
+// MaxBy keeps the first of the largest elements according to less.
+func MaxBy[T any](less func(a, b T) bool) Collector[T, Optional[T]] {
+	return MinBy(func(a, b T) bool {
+		return less(b, a)
+	})
+}
+
+// Joining formats every element with fmt.Sprint and joins them with sep
+// between prefix and suffix.
+func Joining[T any](sep, prefix, suffix string) Collector[T, string] {
+	return func() (func(T), func() string) {
+		var parts []string
+		return func(el T) {
+				parts = append(parts, fmt.Sprint(el))
+			}, func() string {
+				return prefix + strings.Join(parts, sep) + suffix
+			}
+	}
+}
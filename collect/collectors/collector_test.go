@@ -0,0 +1,82 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func TestGroupBy(t *testing.T) {
+	words := collect.NewList("apple", "avocado", "banana", "blueberry", "cherry")
+	groups := Collect[string](words, GroupBy(func(s string) byte {
+		return s[0]
+	}))
+	if len(groups) != 3 || !groups['b'].Equal(collect.NewList("banana", "blueberry")) {
+		t.Errorf("expected error, groups=%v", groups)
+	}
+
+	lengths := Collect[string](words, GroupByWith(func(s string) byte {
+		return s[0]
+	}, Mapping(func(s string) int {
+		return len(s)
+	}, Summing[int]())))
+	if lengths['a'] != 12 || lengths['c'] != 6 {
+		t.Errorf("expected error, lengths=%v", lengths)
+	}
+}
+
+func TestPartitionBy(t *testing.T) {
+	numbers := collect.NewList(1, 2, 3, 4, 5)
+	partition := Collect[int](numbers, PartitionBy(func(i int) bool {
+		return i%2 == 0
+	}))
+	if !partition.Matching.Equal(collect.NewList(2, 4)) || !partition.Rest.Equal(collect.NewList(1, 3, 5)) {
+		t.Errorf("expected error, partition=%v", partition)
+	}
+
+	counts := Collect[int](numbers, PartitionByWith(func(i int) bool {
+		return i > 3
+	}, Counting[int]()))
+	if counts.Matching != 2 || counts.Rest != 3 {
+		t.Errorf("expected error, counts=%v", counts)
+	}
+}
+
+func TestToMap(t *testing.T) {
+	words := collect.NewList("a", "bb", "cc")
+	byLength := Collect[string](words, ToMap(func(s string) int {
+		return len(s)
+	}, strings.ToUpper, KeepLast[string]))
+	if byLength[1] != "A" || byLength[2] != "CC" {
+		t.Errorf("expected error, map=%v", byLength)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected error, duplicate key accepted")
+		}
+	}()
+	Collect[string](words, ToMap(func(s string) int {
+		return len(s)
+	}, strings.ToUpper, nil))
+}
+
+func TestAggregates(t *testing.T) {
+	numbers := collect.NewList(3, 1, 4, 1, 5)
+	if joined := Collect[int](numbers, Joining[int](", ", "[", "]")); joined != "[3, 1, 4, 1, 5]" {
+		t.Errorf("expected error, joined=%s", joined)
+	}
+	if avg := Collect[int](numbers, Averaging[int]()); avg != 2.8 {
+		t.Errorf("expected error, average=%f", avg)
+	}
+	less := func(a, b int) bool {
+		return a < b
+	}
+	if min := Collect[int](numbers, MinBy(less)); !min.Present || min.Value != 1 {
+		t.Errorf("expected error, min=%v", min)
+	}
+	if max := Collect[int](collect.NewList[int](), MaxBy(less)); max.Present {
+		t.Errorf("expected error, max=%v", max)
+	}
+}
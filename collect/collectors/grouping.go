@@ -0,0 +1,93 @@
+package collectors
+
+import (
+	"fmt"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+// Partition holds the results for the elements matching a predicate and for
+// the rest.
+type Partition[R any] struct {
+	Matching R
+	Rest     R
+}
+
+func GroupBy[T comparable, K comparable](keyFn func(T) K) Collector[T, map[K]*collect.ArrayList[T]] {
+	return GroupByWith(keyFn, ToList[T]())
+}
+
+// GroupByWith collects the elements of every key with its own downstream
+// accumulation.
+func GroupByWith[T any, K comparable, R any](keyFn func(T) K, downstream Collector[T, R]) Collector[T, map[K]R] {
+	return func() (func(T), func() map[K]R) {
+		type group struct {
+			accumulate func(T)
+			finish     func() R
+		}
+		groups := make(map[K]group)
+		return func(el T) {
+				key := keyFn(el)
+				g, ok := groups[key]
+				if !ok {
+					g.accumulate, g.finish = downstream()
+					groups[key] = g
+				}
+				g.accumulate(el)
+			}, func() map[K]R {
+				result := make(map[K]R, len(groups))
+				for key, g := range groups {
+					result[key] = g.finish()
+				}
+				return result
+			}
+	}
+}
+
+func PartitionBy[T comparable](predicate func(T) bool) Collector[T, Partition[*collect.ArrayList[T]]] {
+	return PartitionByWith(predicate, ToList[T]())
+}
+
+func PartitionByWith[T any, R any](predicate func(T) bool, downstream Collector[T, R]) Collector[T, Partition[R]] {
+	return func() (func(T), func() Partition[R]) {
+		matching, finishMatching := downstream()
+		rest, finishRest := downstream()
+		return func(el T) {
+				if predicate(el) {
+					matching(el)
+				} else {
+					rest(el)
+				}
+			}, func() Partition[R] {
+				return Partition[R]{Matching: finishMatching(), Rest: finishRest()}
+			}
+	}
+}
+
+// ToMap collects a key and value for every element. Values of duplicate keys
+// are combined with merge; a nil merge panics on the first duplicate.
+func ToMap[T any, K comparable, V any](keyFn func(T) K, valueFn func(T) V, merge func(old, new V) V) Collector[T, map[K]V] {
+	return func() (func(T), func() map[K]V) {
+		result := make(map[K]V)
+		return func(el T) {
+				key, value := keyFn(el), valueFn(el)
+				if old, ok := result[key]; ok {
+					if merge == nil {
+						panic(fmt.Sprintf("collectors: duplicate key %v", key))
+					}
+					value = merge(old, value)
+				}
+				result[key] = value
+			}, func() map[K]V {
+				return result
+			}
+	}
+}
+
+func KeepFirst[V any](old, _ V) V {
+	return old
+}
+
+func KeepLast[V any](_, new V) V {
+	return new
+}
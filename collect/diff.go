@@ -0,0 +1,230 @@
+package collect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrPatchMismatch = errors.New("collect: edit script does not match the list")
+
+// Difference holds the elements only present in one of two collections,
+// compared as sets.
+type Difference[T comparable] struct {
+	Added   *HashSet[T]
+	Removed *HashSet[T]
+}
+
+func (d *Difference[T]) IsEmpty() bool {
+	return d.Added.IsEmpty() && d.Removed.IsEmpty()
+}
+
+func (d *Difference[T]) String() string {
+	return fmt.Sprintf("{+%s -%s}", d.Added, d.Removed)
+}
+
+func Diff[T comparable](old, new Collection[T]) *Difference[T] {
+	before, after := NewSetOf(old), NewSetOf(new)
+	added, removed := NewSet[T](), NewSet[T]()
+	for el := range after.Iterator() {
+		if !before.Contains(el) {
+			added.Add(el)
+		}
+	}
+	for el := range before.Iterator() {
+		if !after.Contains(el) {
+			removed.Add(el)
+		}
+	}
+	return &Difference[T]{Added: added, Removed: removed}
+}
+
+type EditOp int
+
+const (
+	Keep EditOp = iota
+	Insert
+	Delete
+)
+
+var editOpNames = []string{"keep", "insert", "delete"}
+
+func (op EditOp) String() string {
+	if op < 0 || int(op) >= len(editOpNames) {
+		return fmt.Sprintf("EditOp(%d)", int(op))
+	}
+	return editOpNames[op]
+}
+
+func (op EditOp) MarshalJSON() ([]byte, error) {
+	if op < 0 || int(op) >= len(editOpNames) {
+		return nil, fmt.Errorf("collect: unknown edit op %d", int(op))
+	}
+	return json.Marshal(editOpNames[op])
+}
+
+func (op *EditOp) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for i, n := range editOpNames {
+		if n == name {
+			*op = EditOp(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("collect: unknown edit op %q", name)
+}
+
+// Edit is one step of an EditScript. OldIndex is -1 for Insert and NewIndex
+// is -1 for Delete.
+type Edit[T comparable] struct {
+	Op       EditOp `json:"op"`
+	OldIndex int    `json:"oldIndex"`
+	NewIndex int    `json:"newIndex"`
+	Element  T      `json:"element"`
+}
+
+// EditScript turns one list into another, covering every element of both in
+// order.
+type EditScript[T comparable] []Edit[T]
+
+// DiffList returns the shortest edit script from old to new, computed with
+// the linear-space variant of Myers' algorithm, which splits the lists around
+// a middle snake and only keeps two diagonals arrays per split.
+func DiffList[T comparable](old, new *ArrayList[T]) EditScript[T] {
+	d := &differ[T]{a: *old.data, b: *new.data}
+	d.compare(0, len(d.a), 0, len(d.b))
+	return d.script
+}
+
+type differ[T comparable] struct {
+	a, b   []T
+	script EditScript[T]
+}
+
+func (d *differ[T]) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.keep(aLo, bLo)
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-1-suffix] == d.b[bHi-1-suffix] {
+		suffix++
+	}
+	aHi, bHi = aHi-suffix, bHi-suffix
+
+	switch {
+	case aLo == aHi:
+		for y := bLo; y < bHi; y++ {
+			d.script = append(d.script, Edit[T]{Op: Insert, OldIndex: -1, NewIndex: y, Element: d.b[y]})
+		}
+	case bLo == bHi:
+		for x := aLo; x < aHi; x++ {
+			d.script = append(d.script, Edit[T]{Op: Delete, OldIndex: x, NewIndex: -1, Element: d.a[x]})
+		}
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, aLo+x, bLo, bLo+y)
+		for i := 0; i < u-x; i++ {
+			d.keep(aLo+x+i, bLo+y+i)
+		}
+		d.compare(aLo+u, aHi, bLo+v, bHi)
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.keep(aHi+i, bHi+i)
+	}
+}
+
+func (d *differ[T]) keep(x, y int) {
+	d.script = append(d.script, Edit[T]{Op: Keep, OldIndex: x, NewIndex: y, Element: d.a[x]})
+}
+
+// middleSnake returns the start (x, y) and end (u, v) of the middle snake of
+// an optimal path, relative to aLo and bLo. The ranges must differ at both
+// ends, so that the path has at least two edits and both halves around the
+// snake are smaller than the whole.
+func (d *differ[T]) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	limit := (n + m + 1) / 2
+	offset := limit + 1
+	forward := make([]int, 2*offset+1)
+	backward := make([]int, 2*offset+1)
+
+	for step := 0; step <= limit; step++ {
+		for k := -step; k <= step; k += 2 {
+			var px int
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				px = forward[offset+k+1]
+			} else {
+				px = forward[offset+k-1] + 1
+			}
+			py := px - k
+			sx, sy := px, py
+			for px < n && py < m && d.a[aLo+px] == d.b[bLo+py] {
+				px++
+				py++
+			}
+			forward[offset+k] = px
+			if r := delta - k; odd && r >= -(step-1) && r <= step-1 && px+backward[offset+r] >= n {
+				return sx, sy, px, py
+			}
+		}
+		for k := -step; k <= step; k += 2 {
+			var px int
+			if k == -step || (k != step && backward[offset+k-1] < backward[offset+k+1]) {
+				px = backward[offset+k+1]
+			} else {
+				px = backward[offset+k-1] + 1
+			}
+			py := px - k
+			sx, sy := px, py
+			for px < n && py < m && d.a[aHi-1-px] == d.b[bHi-1-py] {
+				px++
+				py++
+			}
+			backward[offset+k] = px
+			if r := delta - k; !odd && r >= -step && r <= step && px+forward[offset+r] >= n {
+				return n - px, m - py, n - sx, m - sy
+			}
+		}
+	}
+	panic("collect: no middle snake")
+}
+
+// Patch applies script to list in place. It returns ErrPatchMismatch and
+// leaves list unchanged if the kept and deleted elements of script do not
+// match list exactly.
+func Patch[T comparable](list *ArrayList[T], script EditScript[T]) error {
+	data := *list.data
+	result := make([]T, 0, len(data))
+	next := 0
+	for _, edit := range script {
+		switch edit.Op {
+		case Keep, Delete:
+			if edit.OldIndex != next || next >= len(data) || data[next] != edit.Element {
+				return fmt.Errorf("%w: %s of %v at %d", ErrPatchMismatch, edit.Op, edit.Element, edit.OldIndex)
+			}
+			if edit.Op == Keep {
+				result = append(result, edit.Element)
+			}
+			next++
+		case Insert:
+			result = append(result, edit.Element)
+		default:
+			return fmt.Errorf("collect: unknown edit op %d", int(edit.Op))
+		}
+	}
+	if next != len(data) {
+		return fmt.Errorf("%w: %d elements not covered", ErrPatchMismatch, len(data)-next)
+	}
+
+	list.Clear()
+	list.AddAllSlice(result)
+	return nil
+}
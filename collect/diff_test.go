@@ -0,0 +1,89 @@
+package collect
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	diff := Diff[string](NewSet("a", "b", "c"), NewList("b", "c", "d", "d"))
+	if !diff.Added.Equal(NewSet("d")) || !diff.Removed.Equal(NewSet("a")) {
+		t.Errorf("expected error, diff=%s", diff)
+	}
+	if !Diff[int](NewList(1, 2), NewSet(2, 1)).IsEmpty() {
+		t.Errorf("expected error, diff not empty")
+	}
+}
+
+func TestDiffList_Patch(t *testing.T) {
+	tests := [][2]string{
+		{"ABCABBA", "CBABAC"},
+		{"", "abc"},
+		{"abc", ""},
+		{"abc", "abc"},
+		{"kitten", "sitting"},
+	}
+	for _, test := range tests {
+		before, after := NewList(strings.Split(test[0], "")...), NewList(strings.Split(test[1], "")...)
+		script := DiffList(before, after)
+		if err := Patch(before, script); err != nil || !before.Equal(after) {
+			t.Errorf("expected error, patched=%s, want=%s (%v)", before, after, err)
+		}
+	}
+
+	script := DiffList(NewList(strings.Split("ABCABBA", "")...), NewList(strings.Split("CBABAC", "")...))
+	changes := 0
+	for _, edit := range script {
+		if edit.Op != Keep {
+			changes++
+		}
+	}
+	if changes != 5 {
+		t.Errorf("expected error, changes=%d, got=%d", 5, changes)
+	}
+}
+
+func TestEditScript_JSON(t *testing.T) {
+	script := DiffList(NewList(1, 2, 3), NewList(1, 3, 4))
+	data, err := json.Marshal(script)
+	if err != nil || !strings.Contains(string(data), `"op":"delete"`) {
+		t.Fatalf("expected error, json=%s (%v)", data, err)
+	}
+
+	var decoded EditScript[int]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	list := NewList(1, 2, 3)
+	if err := Patch(list, decoded); err != nil || !list.Equal(NewList(1, 3, 4)) {
+		t.Errorf("expected error, list=%s (%v)", list, err)
+	}
+	if err := Patch(NewList(9, 9), decoded); !errors.Is(err, ErrPatchMismatch) {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
+
+func TestDiffList_Large(t *testing.T) {
+	old, updated := make([]int, 5000), make([]int, 5000)
+	for i := range old {
+		old[i], updated[i] = i, i+5000
+	}
+	before, after := NewList(old...), NewList(updated...)
+	script := DiffList(before, after)
+	if len(script) != 10000 {
+		t.Errorf("expected error, edits=%d, got=%d", 10000, len(script))
+	}
+	if err := Patch(before, script); err != nil || !before.Equal(after) {
+		t.Errorf("expected error, patch failed (%v)", err)
+	}
+
+	for i := range updated {
+		updated[i] = old[(i*7)%len(old)]
+	}
+	before, after = NewList(old...), NewList(updated...)
+	if err := Patch(before, DiffList(before, after)); err != nil || !before.Equal(after) {
+		t.Errorf("expected error, patch failed (%v)", err)
+	}
+}
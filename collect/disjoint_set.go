@@ -0,0 +1,105 @@
+package collect
+
+type DisjointSet[T comparable] struct {
+	parent map[T]T
+	rank   map[T]int
+	count  int
+}
+
+func NewDisjointSet[T comparable](elements ...T) *DisjointSet[T] {
+	set := &DisjointSet[T]{
+		parent: make(map[T]T),
+		rank:   make(map[T]int),
+	}
+	for _, el := range elements {
+		set.MakeSet(el)
+	}
+	return set
+}
+
+func (d *DisjointSet[T]) MakeSet(element T) bool {
+	if _, ok := d.parent[element]; ok {
+		return false
+	}
+	d.parent[element] = element
+	d.count++
+	return true
+}
+
+func (d *DisjointSet[T]) Find(element T) (T, bool) {
+	root, ok := d.parent[element]
+	if !ok {
+		return root, false
+	}
+	for root != d.parent[root] {
+		root = d.parent[root]
+	}
+	for element != root {
+		next := d.parent[element]
+		d.parent[element] = root
+		element = next
+	}
+	return root, true
+}
+
+// Union merges the sets of a and b, creating singleton sets for unknown
+// elements first. It reports whether two distinct sets were merged.
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	d.MakeSet(a)
+	d.MakeSet(b)
+	rootA, _ := d.Find(a)
+	rootB, _ := d.Find(b)
+	if rootA == rootB {
+		return false
+	}
+
+	switch {
+	case d.rank[rootA] < d.rank[rootB]:
+		d.parent[rootA] = rootB
+	case d.rank[rootA] > d.rank[rootB]:
+		d.parent[rootB] = rootA
+	default:
+		d.parent[rootB] = rootA
+		d.rank[rootA]++
+	}
+	d.count--
+	return true
+}
+
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	rootA, okA := d.Find(a)
+	rootB, okB := d.Find(b)
+	return okA && okB && rootA == rootB
+}
+
+func (d *DisjointSet[T]) Contains(element T) bool {
+	_, ok := d.parent[element]
+	return ok
+}
+
+func (d *DisjointSet[T]) SetCount() int {
+	return d.count
+}
+
+func (d *DisjointSet[T]) Size() int {
+	return len(d.parent)
+}
+
+func (d *DisjointSet[T]) Groups() *ArrayList[*HashSet[T]] {
+	groups := make(map[T]*HashSet[T], d.count)
+	for el := range d.parent {
+		root, _ := d.Find(el)
+		group, ok := groups[root]
+		if !ok {
+			group = NewSet[T]()
+			groups[root] = group
+		}
+		group.Add(el)
+	}
+
+	result := make([]*HashSet[T], 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group)
+	}
+	return NewList(result...)
+}
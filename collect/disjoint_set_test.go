@@ -0,0 +1,41 @@
+package collect
+
+import "testing"
+
+func TestDisjointSet_Union(t *testing.T) {
+	set := NewDisjointSet("alice", "bob", "carol", "dave")
+	if set.SetCount() != 4 {
+		t.Errorf("expected error, sets=%d, got=%d", 4, set.SetCount())
+	}
+	if !set.Union("alice", "bob") || !set.Union("carol", "erin") || set.Union("bob", "alice") {
+		t.Errorf("expected error, incorrect union result")
+	}
+	set.Union("erin", "dave")
+
+	if !set.Connected("carol", "dave") || set.Connected("alice", "dave") {
+		t.Errorf("expected error, incorrect connectivity")
+	}
+	if set.Connected("alice", "frank") {
+		t.Errorf("expected error, unknown element connected")
+	}
+	if set.SetCount() != 2 || set.Size() != 5 {
+		t.Errorf("expected error, sets=%d, got=%d", 2, set.SetCount())
+	}
+}
+
+func TestDisjointSet_Groups(t *testing.T) {
+	set := NewDisjointSet[int]()
+	for i := 0; i < thousand; i++ {
+		set.Union(i, i%ten)
+	}
+
+	groups := set.Groups()
+	if groups.Size() != ten {
+		t.Errorf("expected error, groups=%d, got=%d", ten, groups.Size())
+	}
+	groups.ForEach(func(group *HashSet[int]) {
+		if group.Size() != hundred {
+			t.Errorf("expected error, group size=%d, got=%d", hundred, group.Size())
+		}
+	})
+}
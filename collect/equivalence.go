@@ -0,0 +1,50 @@
+package collect
+
+import (
+	"bytes"
+	"hash/maphash"
+	"strings"
+)
+
+// Equivalence decides when two elements are the same and hashes them consistently:
+// Equal(a, b) must imply Hash(a) == Hash(b).
+type Equivalence[T any] interface {
+	Equal(a, b T) bool
+	Hash(element T) uint64
+}
+
+type equivalence[T any] struct {
+	equal func(a, b T) bool
+	hash  func(element T) uint64
+}
+
+func NewEquivalence[T any](equal func(a, b T) bool, hash func(element T) uint64) Equivalence[T] {
+	return &equivalence[T]{equal: equal, hash: hash}
+}
+
+func (e *equivalence[T]) Equal(a, b T) bool {
+	return e.equal(a, b)
+}
+
+func (e *equivalence[T]) Hash(element T) uint64 {
+	return e.hash(element)
+}
+
+var equivalenceSeed = maphash.MakeSeed()
+
+func BytesEquivalence() Equivalence[[]byte] {
+	return NewEquivalence(bytes.Equal, func(element []byte) uint64 {
+		return maphash.Bytes(equivalenceSeed, element)
+	})
+}
+
+func FoldCaseEquivalence() Equivalence[string] {
+	return NewEquivalence(
+		func(a, b string) bool {
+			return strings.ToLower(a) == strings.ToLower(b)
+		},
+		func(element string) uint64 {
+			return maphash.String(equivalenceSeed, strings.ToLower(element))
+		},
+	)
+}
@@ -0,0 +1,54 @@
+package collect
+
+import "testing"
+
+func TestEquivalenceSet_FoldCase(t *testing.T) {
+	set := NewSetWith(FoldCaseEquivalence(), "Go", "GO", "go", "Rust")
+	if set.Size() != 2 {
+		t.Errorf("expected error, size=%d, got=%d", 2, set.Size())
+	}
+	if !set.Contains("gO") {
+		t.Errorf("expected error, not containing=%s", "gO")
+	}
+	if !set.Remove("RUST") || set.Contains("rust") {
+		t.Errorf("expected error, not deleted %s", "RUST")
+	}
+	if set.Remove("rust") {
+		t.Errorf("expected error, deleted absent %s", "rust")
+	}
+}
+
+func TestEquivalenceSet_Bytes(t *testing.T) {
+	set := NewSetWith(BytesEquivalence(), []byte("a"), []byte("b"), []byte("a"))
+	if set.Size() != 2 {
+		t.Errorf("expected error, size=%d, got=%d", 2, set.Size())
+	}
+
+	set.RemoveIf(func(b []byte) bool {
+		return string(b) == "a"
+	})
+	if set.Contains([]byte("a")) || !set.Contains([]byte("b")) {
+		t.Errorf("expected error, incorrect set after RemoveIf %s", set)
+	}
+
+	other := NewSetWith(BytesEquivalence(), []byte("b"))
+	if !set.Equal(other) {
+		t.Errorf("expected error, sets not equal %s %s", set, other)
+	}
+}
+
+func TestEquivalenceList(t *testing.T) {
+	list := NewListWith(BytesEquivalence(), []byte("x"), []byte("y"), []byte("x"))
+	if list.IndexOf([]byte("y")) != 1 {
+		t.Errorf("expected error, index=%d, got=%d", 1, list.IndexOf([]byte("y")))
+	}
+	if !list.Remove([]byte("x")) || list.Size() != 2 {
+		t.Errorf("expected error, not deleted %s", "x")
+	}
+	if string(list.Get(0)) != "y" || !list.Contains([]byte("x")) {
+		t.Errorf("expected error, incorrect list %s", list)
+	}
+	if _, ok := list.SafeGet(-1); ok {
+		t.Errorf("expected error, got element at %d", -1)
+	}
+}
@@ -0,0 +1,278 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+var (
+	ErrUndirected     = errors.New("graph: operation requires a directed graph")
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+)
+
+type CycleError[V comparable] struct {
+	Cycle *collect.ArrayList[V]
+}
+
+func (e *CycleError[V]) Error() string {
+	return fmt.Sprintf("graph: cycle detected %s", e.Cycle)
+}
+
+func (g *Graph[V]) BFS(start V) <-chan V {
+	var order []V
+	if g.HasVertex(start) {
+		visited := collect.NewSet(start)
+		queue := collect.NewQueue(start)
+		for !queue.IsEmpty() {
+			v := queue.Pool()
+			order = append(order, v)
+			g.adjacency[v].ForEach(func(next V) {
+				if !visited.Contains(next) {
+					visited.Add(next)
+					queue.Offer(next)
+				}
+			})
+		}
+	}
+	return iterator(order)
+}
+
+func (g *Graph[V]) DFS(start V) <-chan V {
+	var order []V
+	if g.HasVertex(start) {
+		visited := collect.NewSet[V]()
+		stack := []V{start}
+		for len(stack) > 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited.Contains(v) {
+				continue
+			}
+			visited.Add(v)
+			order = append(order, v)
+			g.adjacency[v].ForEach(func(next V) {
+				if !visited.Contains(next) {
+					stack = append(stack, next)
+				}
+			})
+		}
+	}
+	return iterator(order)
+}
+
+// TopologicalSort orders the vertices so that every edge points forward. If
+// the graph has a cycle the error is a *CycleError holding the vertices of
+// one cycle, with the first vertex repeated at the end.
+func (g *Graph[V]) TopologicalSort() (*collect.ArrayList[V], error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+
+	const (
+		white = iota
+		grey
+		black
+	)
+	color := make(map[V]int, len(g.adjacency))
+	var order, path []V
+
+	var visit func(v V) []V
+	visit = func(v V) []V {
+		color[v] = grey
+		path = append(path, v)
+		for next := range g.adjacency[v].Iterator() {
+			switch color[next] {
+			case grey:
+				for i, p := range path {
+					if p == next {
+						return append(append([]V(nil), path[i:]...), next)
+					}
+				}
+			case white:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		color[v] = black
+		order = append(order, v)
+		return nil
+	}
+
+	for v := range g.adjacency {
+		if color[v] == white {
+			if cycle := visit(v); cycle != nil {
+				return nil, &CycleError[V]{Cycle: collect.NewList(cycle...)}
+			}
+		}
+	}
+
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return collect.NewList(order...), nil
+}
+
+type ShortestPaths[V comparable] struct {
+	source   V
+	distance map[V]float64
+	previous map[V]V
+}
+
+func (p *ShortestPaths[V]) DistanceTo(v V) (float64, bool) {
+	distance, ok := p.distance[v]
+	return distance, ok
+}
+
+func (p *ShortestPaths[V]) PathTo(v V) *collect.ArrayList[V] {
+	if _, ok := p.distance[v]; !ok {
+		return collect.NewList[V]()
+	}
+	path := []V{v}
+	for v != p.source {
+		v = p.previous[v]
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return collect.NewList(path...)
+}
+
+func (g *Graph[V]) Dijkstra(source V) (*ShortestPaths[V], error) {
+	for _, weight := range g.weights {
+		if weight < 0 {
+			return nil, ErrNegativeWeight
+		}
+	}
+
+	paths := &ShortestPaths[V]{
+		source:   source,
+		distance: make(map[V]float64),
+		previous: make(map[V]V),
+	}
+	if !g.HasVertex(source) {
+		return paths, nil
+	}
+
+	paths.distance[source] = 0
+	queue := &priorityQueue[V]{{vertex: source}}
+	done := collect.NewSet[V]()
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(distanceItem[V])
+		if done.Contains(item.vertex) {
+			continue
+		}
+		done.Add(item.vertex)
+
+		g.adjacency[item.vertex].ForEach(func(next V) {
+			distance := item.distance + g.weights[edgeKey[V]{item.vertex, next}]
+			if current, ok := paths.distance[next]; !ok || distance < current {
+				paths.distance[next] = distance
+				paths.previous[next] = item.vertex
+				heap.Push(queue, distanceItem[V]{vertex: next, distance: distance})
+			}
+		})
+	}
+	return paths, nil
+}
+
+func (g *Graph[V]) ShortestPath(from, to V) (*collect.ArrayList[V], float64, error) {
+	paths, err := g.Dijkstra(from)
+	if err != nil {
+		return nil, 0, err
+	}
+	distance, ok := paths.DistanceTo(to)
+	if !ok {
+		return collect.NewList[V](), math.Inf(1), nil
+	}
+	return paths.PathTo(to), distance, nil
+}
+
+// StronglyConnectedComponents uses Tarjan's algorithm. On an undirected graph
+// the components are the connected components.
+func (g *Graph[V]) StronglyConnectedComponents() *collect.ArrayList[*collect.HashSet[V]] {
+	index := make(map[V]int, len(g.adjacency))
+	low := make(map[V]int, len(g.adjacency))
+	onStack := collect.NewSet[V]()
+	var stack []V
+	components := collect.NewList[*collect.HashSet[V]]()
+
+	var connect func(v V)
+	connect = func(v V) {
+		index[v] = len(index)
+		low[v] = index[v]
+		stack = append(stack, v)
+		onStack.Add(v)
+
+		g.adjacency[v].ForEach(func(next V) {
+			if _, ok := index[next]; !ok {
+				connect(next)
+				if low[next] < low[v] {
+					low[v] = low[next]
+				}
+			} else if onStack.Contains(next) && index[next] < low[v] {
+				low[v] = index[next]
+			}
+		})
+
+		if low[v] == index[v] {
+			component := collect.NewSet[V]()
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack.Remove(top)
+				component.Add(top)
+				if top == v {
+					break
+				}
+			}
+			components.Add(component)
+		}
+	}
+
+	for v := range g.adjacency {
+		if _, ok := index[v]; !ok {
+			connect(v)
+		}
+	}
+	return components
+}
+
+type distanceItem[V comparable] struct {
+	vertex   V
+	distance float64
+}
+
+type priorityQueue[V comparable] []distanceItem[V]
+
+func (q priorityQueue[V]) Len() int           { return len(q) }
+func (q priorityQueue[V]) Less(i, j int) bool { return q[i].distance < q[j].distance }
+func (q priorityQueue[V]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityQueue[V]) Push(x any) {
+	*q = append(*q, x.(distanceItem[V]))
+}
+
+func (q *priorityQueue[V]) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+func iterator[V comparable](order []V) <-chan V {
+	pool := make(chan V, len(order))
+	defer close(pool)
+
+	for _, v := range order {
+		pool <- v
+	}
+
+	return pool
+}
@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+type Edge[V comparable] struct {
+	From   V
+	To     V
+	Weight float64
+}
+
+type edgeKey[V comparable] struct {
+	from V
+	to   V
+}
+
+type Graph[V comparable] struct {
+	directed  bool
+	adjacency map[V]*collect.HashSet[V]
+	weights   map[edgeKey[V]]float64
+}
+
+func NewDirected[V comparable]() *Graph[V] {
+	return &Graph[V]{
+		directed:  true,
+		adjacency: make(map[V]*collect.HashSet[V]),
+		weights:   make(map[edgeKey[V]]float64),
+	}
+}
+
+func NewUndirected[V comparable]() *Graph[V] {
+	return &Graph[V]{
+		adjacency: make(map[V]*collect.HashSet[V]),
+		weights:   make(map[edgeKey[V]]float64),
+	}
+}
+
+func (g *Graph[V]) IsDirected() bool {
+	return g.directed
+}
+
+func (g *Graph[V]) AddVertex(v V) {
+	if _, ok := g.adjacency[v]; !ok {
+		g.adjacency[v] = collect.NewSet[V]()
+	}
+}
+
+func (g *Graph[V]) RemoveVertex(v V) bool {
+	if _, ok := g.adjacency[v]; !ok {
+		return false
+	}
+	for from := range g.adjacency {
+		g.RemoveEdge(from, v)
+	}
+	for to := range g.adjacency[v].Iterator() {
+		g.RemoveEdge(v, to)
+	}
+	delete(g.adjacency, v)
+	return true
+}
+
+func (g *Graph[V]) AddEdge(from, to V) {
+	g.AddWeightedEdge(from, to, 1)
+}
+
+func (g *Graph[V]) AddWeightedEdge(from, to V, weight float64) {
+	g.AddVertex(from)
+	g.AddVertex(to)
+	g.adjacency[from].Add(to)
+	g.weights[edgeKey[V]{from, to}] = weight
+	if !g.directed {
+		g.adjacency[to].Add(from)
+		g.weights[edgeKey[V]{to, from}] = weight
+	}
+}
+
+func (g *Graph[V]) RemoveEdge(from, to V) bool {
+	if !g.HasEdge(from, to) {
+		return false
+	}
+	g.adjacency[from].Remove(to)
+	delete(g.weights, edgeKey[V]{from, to})
+	if !g.directed {
+		g.adjacency[to].Remove(from)
+		delete(g.weights, edgeKey[V]{to, from})
+	}
+	return true
+}
+
+func (g *Graph[V]) HasVertex(v V) bool {
+	_, ok := g.adjacency[v]
+	return ok
+}
+
+func (g *Graph[V]) HasEdge(from, to V) bool {
+	_, ok := g.weights[edgeKey[V]{from, to}]
+	return ok
+}
+
+func (g *Graph[V]) Weight(from, to V) (float64, bool) {
+	weight, ok := g.weights[edgeKey[V]{from, to}]
+	return weight, ok
+}
+
+func (g *Graph[V]) Vertices() *collect.HashSet[V] {
+	vertices := collect.NewSet[V]()
+	for v := range g.adjacency {
+		vertices.Add(v)
+	}
+	return vertices
+}
+
+func (g *Graph[V]) Neighbors(v V) *collect.HashSet[V] {
+	neighbors, ok := g.adjacency[v]
+	if !ok {
+		return collect.NewSet[V]()
+	}
+	return collect.NewSetOf[V](neighbors)
+}
+
+func (g *Graph[V]) Edges() *collect.ArrayList[Edge[V]] {
+	edges := collect.NewList[Edge[V]]()
+	added := make(map[edgeKey[V]]bool)
+	for key, weight := range g.weights {
+		if !g.directed && added[edgeKey[V]{key.to, key.from}] {
+			continue
+		}
+		added[key] = true
+		edges.Add(Edge[V]{From: key.from, To: key.to, Weight: weight})
+	}
+	return edges
+}
+
+func (g *Graph[V]) VertexCount() int {
+	return len(g.adjacency)
+}
+
+func (g *Graph[V]) EdgeCount() int {
+	if g.directed {
+		return len(g.weights)
+	}
+	loops := 0
+	for key := range g.weights {
+		if key.from == key.to {
+			loops++
+		}
+	}
+	return (len(g.weights)-loops)/2 + loops
+}
+
+func (g *Graph[V]) String() string {
+	var data []string
+	for v, neighbors := range g.adjacency {
+		data = append(data, fmt.Sprintf("%v:%s", v, neighbors))
+	}
+	return "{" + strings.Join(data, " ") + "}"
+}
@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func TestGraph_Edges(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddWeightedEdge("a", "b", 2)
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "c")
+	if g.EdgeCount() != 3 || g.Edges().Size() != 3 {
+		t.Errorf("expected error, edges=%d, got=%d", 3, g.EdgeCount())
+	}
+	if weight, ok := g.Weight("b", "a"); !ok || weight != 2 {
+		t.Errorf("expected error, weight=%v, got=%v", 2, weight)
+	}
+
+	g.RemoveVertex("b")
+	if g.HasEdge("a", "b") || g.EdgeCount() != 1 || g.VertexCount() != 2 {
+		t.Errorf("expected error, vertex not removed %s", g)
+	}
+}
+
+func TestGraph_Traversal(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 4)
+	g.AddEdge(3, 4)
+	g.AddEdge(5, 1)
+
+	var bfs []int
+	for v := range g.BFS(1) {
+		bfs = append(bfs, v)
+	}
+	if len(bfs) != 4 || bfs[0] != 1 || bfs[3] != 4 {
+		t.Errorf("expected error, incorrect bfs %v", bfs)
+	}
+
+	dfs := collect.NewList[int]()
+	for v := range g.DFS(1) {
+		dfs.Add(v)
+	}
+	if dfs.Size() != 4 || dfs.Get(0) != 1 || dfs.Contains(5) {
+		t.Errorf("expected error, incorrect dfs %s", dfs)
+	}
+}
+
+func TestGraph_TopologicalSort(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("compile", "link")
+	g.AddEdge("generate", "compile")
+	g.AddEdge("link", "package")
+	g.AddEdge("generate", "docs")
+
+	order, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Edges().ForEach(func(edge Edge[string]) {
+		if order.IndexOf(edge.From) > order.IndexOf(edge.To) {
+			t.Errorf("expected error, %s after %s in %s", edge.From, edge.To, order)
+		}
+	})
+
+	g.AddEdge("package", "generate")
+	_, err = g.TopologicalSort()
+	var cycle *CycleError[string]
+	if !errors.As(err, &cycle) || cycle.Cycle.Size() != 5 || cycle.Cycle.Get(0) != cycle.Cycle.Get(4) {
+		t.Errorf("expected error, incorrect cycle %v", err)
+	}
+}
+
+func TestGraph_Dijkstra(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddWeightedEdge("a", "b", 4)
+	g.AddWeightedEdge("a", "c", 1)
+	g.AddWeightedEdge("c", "b", 2)
+	g.AddWeightedEdge("b", "d", 1)
+	g.AddVertex("e")
+
+	path, distance, err := g.ShortestPath("a", "d")
+	if err != nil || distance != 4 || !path.Equal(collect.NewList("a", "c", "b", "d")) {
+		t.Errorf("expected error, path=%s, got=%s %v", "[a c b d]", path, distance)
+	}
+	if path, _, _ := g.ShortestPath("a", "e"); !path.IsEmpty() {
+		t.Errorf("expected error, path to unreachable vertex %s", path)
+	}
+
+	g.AddWeightedEdge("d", "e", -1)
+	if _, err := g.Dijkstra("a"); err != ErrNegativeWeight {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
+
+func TestGraph_StronglyConnectedComponents(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 1)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(5, 4)
+	g.AddVertex(6)
+
+	components := g.StronglyConnectedComponents()
+	if components.Size() != 3 {
+		t.Errorf("expected error, components=%d, got=%d", 3, components.Size())
+	}
+	sizes := collect.NewMultiset[int]()
+	components.ForEach(func(component *collect.HashSet[int]) {
+		sizes.Add(component.Size())
+	})
+	if sizes.Count(3) != 1 || sizes.Count(2) != 1 || sizes.Count(1) != 1 {
+		t.Errorf("expected error, incorrect components %s", components)
+	}
+}
@@ -0,0 +1,220 @@
+package collect
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Interval[K Ordered, V any] struct {
+	Lo    K
+	Hi    K
+	Value V
+}
+
+type intervalNode[K Ordered, V any] struct {
+	interval    Interval[K, V]
+	max         K
+	height      int
+	left, right *intervalNode[K, V]
+}
+
+// IntervalTree stores closed intervals [Lo, Hi] in an AVL tree ordered by
+// (Lo, Hi), with every node tracking the largest Hi below it.
+type IntervalTree[K Ordered, V any] struct {
+	root *intervalNode[K, V]
+	size int
+	mods int
+}
+
+func NewIntervalTree[K Ordered, V any]() *IntervalTree[K, V] {
+	return &IntervalTree[K, V]{}
+}
+
+func (t *IntervalTree[K, V]) Insert(lo, hi K, value V) {
+	if hi < lo {
+		panic(fmt.Sprintf("collect: invalid interval [%v, %v]", lo, hi))
+	}
+	t.root = t.root.insert(Interval[K, V]{Lo: lo, Hi: hi, Value: value})
+	t.size++
+	t.mods++
+}
+
+// Delete removes one interval with exactly the bounds [lo, hi].
+func (t *IntervalTree[K, V]) Delete(lo, hi K) bool {
+	var deleted bool
+	t.root, deleted = t.root.delete(lo, hi)
+	if deleted {
+		t.size--
+		t.mods++
+	}
+	return deleted
+}
+
+func (t *IntervalTree[K, V]) Overlapping(lo, hi K) *AnyList[Interval[K, V]] {
+	result := NewAnyList[Interval[K, V]]()
+	t.root.overlapping(lo, hi, result)
+	return result
+}
+
+func (t *IntervalTree[K, V]) Containing(point K) *AnyList[Interval[K, V]] {
+	return t.Overlapping(point, point)
+}
+
+func (t *IntervalTree[K, V]) Size() int {
+	return t.size
+}
+
+func (t *IntervalTree[K, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+func (t *IntervalTree[K, V]) Clear() {
+	t.root = nil
+	t.size = 0
+	t.mods++
+}
+
+func (t *IntervalTree[K, V]) ForEach(do func(Interval[K, V])) {
+	mods := t.mods
+	t.root.forEach(func(interval Interval[K, V]) {
+		do(interval)
+		checkModifications(mods, t.mods)
+	})
+}
+
+func (t *IntervalTree[K, V]) String() string {
+	var data []string
+	t.ForEach(func(interval Interval[K, V]) {
+		data = append(data, fmt.Sprintf("[%v, %v]:%v", interval.Lo, interval.Hi, interval.Value))
+	})
+	return "[" + strings.Join(data, " ") + "]"
+}
+
+func (n *intervalNode[K, V]) insert(interval Interval[K, V]) *intervalNode[K, V] {
+	if n == nil {
+		return &intervalNode[K, V]{interval: interval, max: interval.Hi, height: 1}
+	}
+	if less(interval.Lo, interval.Hi, n.interval.Lo, n.interval.Hi) {
+		n.left = n.left.insert(interval)
+	} else {
+		n.right = n.right.insert(interval)
+	}
+	return n.balance()
+}
+
+func (n *intervalNode[K, V]) delete(lo, hi K) (*intervalNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	switch {
+	case lo == n.interval.Lo && hi == n.interval.Hi:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		var successor Interval[K, V]
+		n.right, successor = n.right.deleteMin()
+		n.interval = successor
+		deleted = true
+	case less(lo, hi, n.interval.Lo, n.interval.Hi):
+		n.left, deleted = n.left.delete(lo, hi)
+	default:
+		n.right, deleted = n.right.delete(lo, hi)
+	}
+	return n.balance(), deleted
+}
+
+func (n *intervalNode[K, V]) deleteMin() (*intervalNode[K, V], Interval[K, V]) {
+	if n.left == nil {
+		return n.right, n.interval
+	}
+	var first Interval[K, V]
+	n.left, first = n.left.deleteMin()
+	return n.balance(), first
+}
+
+func (n *intervalNode[K, V]) overlapping(lo, hi K, result *AnyList[Interval[K, V]]) {
+	if n == nil || n.max < lo {
+		return
+	}
+	n.left.overlapping(lo, hi, result)
+	if n.interval.Lo <= hi && lo <= n.interval.Hi {
+		result.Add(n.interval)
+	}
+	if n.interval.Lo <= hi {
+		n.right.overlapping(lo, hi, result)
+	}
+}
+
+func (n *intervalNode[K, V]) forEach(do func(Interval[K, V])) {
+	if n == nil {
+		return
+	}
+	n.left.forEach(do)
+	do(n.interval)
+	n.right.forEach(do)
+}
+
+func (n *intervalNode[K, V]) balance() *intervalNode[K, V] {
+	n.update()
+	switch factor := n.left.getHeight() - n.right.getHeight(); {
+	case factor > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case factor < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *intervalNode[K, V]) rotateLeft() *intervalNode[K, V] {
+	root := n.right
+	n.right = root.left
+	root.left = n
+	n.update()
+	root.update()
+	return root
+}
+
+func (n *intervalNode[K, V]) rotateRight() *intervalNode[K, V] {
+	root := n.left
+	n.left = root.right
+	root.right = n
+	n.update()
+	root.update()
+	return root
+}
+
+func (n *intervalNode[K, V]) update() {
+	n.height = 1 + n.left.getHeight()
+	if h := n.right.getHeight(); h >= n.height {
+		n.height = 1 + h
+	}
+	n.max = n.interval.Hi
+	if n.left != nil && n.left.max > n.max {
+		n.max = n.left.max
+	}
+	if n.right != nil && n.right.max > n.max {
+		n.max = n.right.max
+	}
+}
+
+func (n *intervalNode[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func less[K Ordered](lo1, hi1, lo2, hi2 K) bool {
+	return lo1 < lo2 || (lo1 == lo2 && hi1 < hi2)
+}
@@ -0,0 +1,84 @@
+package collect
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIntervalTree_Overlapping(t *testing.T) {
+	tree := NewIntervalTree[int, string]()
+	tree.Insert(1, 5, "a")
+	tree.Insert(3, 8, "b")
+	tree.Insert(10, 12, "c")
+	tree.Insert(3, 8, "d")
+
+	overlapping := tree.Overlapping(6, 10)
+	if overlapping.Size() != 3 {
+		t.Errorf("expected error, overlapping=%d, got=%d", 3, overlapping.Size())
+	}
+	if containing := tree.Containing(2); containing.Size() != 1 || containing.Get(0).Value != "a" {
+		t.Errorf("expected error, incorrect containing %s", containing)
+	}
+
+	if !tree.Delete(3, 8) || tree.Delete(4, 8) {
+		t.Errorf("expected error, incorrect Delete")
+	}
+	if tree.Size() != 3 || tree.Containing(7).Size() != 1 {
+		t.Errorf("expected error, incorrect tree after Delete %s", tree)
+	}
+}
+
+func TestIntervalTree_Random(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	tree := NewIntervalTree[int, int]()
+	var intervals [][2]int
+	for i := 0; i < thousand; i++ {
+		lo := random.Intn(thousand)
+		hi := lo + random.Intn(ten*ten)
+		tree.Insert(lo, hi, i)
+		intervals = append(intervals, [2]int{lo, hi})
+	}
+	for i := 0; i < hundred; i++ {
+		idx := random.Intn(len(intervals))
+		tree.Delete(intervals[idx][0], intervals[idx][1])
+		intervals = append(intervals[:idx], intervals[idx+1:]...)
+	}
+
+	for i := 0; i < hundred; i++ {
+		point := random.Intn(thousand)
+		expected := 0
+		for _, interval := range intervals {
+			if interval[0] <= point && point <= interval[1] {
+				expected++
+			}
+		}
+		if got := tree.Containing(point).Size(); got != expected {
+			t.Errorf("expected error, containing=%d, got=%d", expected, got)
+		}
+	}
+	if tree.root.height > 15 {
+		t.Errorf("expected error, tree not balanced, height=%d", tree.root.height)
+	}
+}
+
+func TestRangeSet(t *testing.T) {
+	set := NewRangeSet(Range[int]{1, 3}, Range[int]{3, 5}, Range[int]{10, 12})
+	set.Add(4, 7)
+	if !set.Equal(NewRangeSet(Range[int]{1, 7}, Range[int]{10, 12})) {
+		t.Errorf("expected error, ranges not coalesced %s", set)
+	}
+	if !set.Contains(6) || set.Contains(7) || !set.Encloses(2, 7) || set.Encloses(6, 11) {
+		t.Errorf("expected error, incorrect Contains %s", set)
+	}
+
+	set.Remove(2, 4)
+	if !set.Equal(NewRangeSet(Range[int]{1, 2}, Range[int]{4, 7}, Range[int]{10, 12})) {
+		t.Errorf("expected error, incorrect Remove %s", set)
+	}
+	if span, _ := set.Span(); span != (Range[int]{1, 12}) {
+		t.Errorf("expected error, span=%s, got=%s", Range[int]{1, 12}, span)
+	}
+	if complement := set.Complement(0, 20); !complement.Equal(NewRangeSet(Range[int]{0, 1}, Range[int]{2, 4}, Range[int]{7, 10}, Range[int]{12, 20})) {
+		t.Errorf("expected error, incorrect Complement %s", complement)
+	}
+}
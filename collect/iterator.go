@@ -0,0 +1,116 @@
+package collect
+
+import "fmt"
+
+// ConcurrentModificationError is the panic value raised when a collection is
+// structurally modified while ForEach or an Iterator is traversing it, other
+// than through the iterator's own Remove.
+type ConcurrentModificationError struct {
+	Expected int
+	Actual   int
+}
+
+func (e *ConcurrentModificationError) Error() string {
+	return fmt.Sprintf("collect: concurrent modification during iteration (expected %d modifications, got %d)",
+		e.Expected, e.Actual)
+}
+
+func checkModifications(expected, actual int) {
+	if expected != actual {
+		panic(&ConcurrentModificationError{Expected: expected, Actual: actual})
+	}
+}
+
+// Iterator traverses a live collection, unlike the snapshot returned by
+// Collection.Iterator, and fails fast if the collection changes underneath it.
+// Remove deletes the element last returned by Next.
+type Iterator[T any] interface {
+	HasNext() bool
+	Next() T
+	Remove()
+}
+
+type sliceIterator[T any] struct {
+	data     *[]T
+	mods     *int
+	expected int
+	cursor   int
+	last     int
+}
+
+func newSliceIterator[T any](data *[]T, mods *int) *sliceIterator[T] {
+	return &sliceIterator[T]{data: data, mods: mods, expected: *mods, last: -1}
+}
+
+func (it *sliceIterator[T]) HasNext() bool {
+	return it.cursor < len(*it.data)
+}
+
+func (it *sliceIterator[T]) Next() T {
+	checkModifications(it.expected, *it.mods)
+	if it.cursor >= len(*it.data) {
+		panic("collect: Next on exhausted Iterator")
+	}
+	it.last = it.cursor
+	it.cursor++
+	return (*it.data)[it.last]
+}
+
+func (it *sliceIterator[T]) Remove() {
+	checkModifications(it.expected, *it.mods)
+	if it.last < 0 {
+		panic("collect: Remove without Next")
+	}
+	// The capacity limit makes append copy, so that snapshots sharing the
+	// data are left untouched.
+	*it.data = append((*it.data)[:it.last:it.last], (*it.data)[it.last+1:]...)
+	it.cursor = it.last
+	it.last = -1
+	*it.mods++
+	it.expected = *it.mods
+}
+
+// NewIterator returns a fail-fast Iterator over a snapshot of elements for a
+// collection that counts its structural modifications in mods. Remove passes
+// the element last returned by Next to remove, together with its current
+// position in the collection, and remove must update mods.
+func NewIterator[T any](elements []T, mods func() int, remove func(index int, element T)) Iterator[T] {
+	return &snapshotIterator[T]{elements: elements, mods: mods, remove: remove, expected: mods()}
+}
+
+type snapshotIterator[T any] struct {
+	elements []T
+	mods     func() int
+	remove   func(int, T)
+	expected int
+	cursor   int
+	removed  int
+	last     bool
+}
+
+func (it *snapshotIterator[T]) HasNext() bool {
+	return it.cursor < len(it.elements)
+}
+
+func (it *snapshotIterator[T]) Next() T {
+	checkModifications(it.expected, it.mods())
+	if it.cursor >= len(it.elements) {
+		panic("collect: Next on exhausted Iterator")
+	}
+	it.cursor++
+	it.last = true
+	return it.elements[it.cursor-1]
+}
+
+func (it *snapshotIterator[T]) Remove() {
+	checkModifications(it.expected, it.mods())
+	if !it.last {
+		panic("collect: Remove without Next")
+	}
+	// Every earlier element removed through the iterator shifted this one
+	// one position to the front.
+	it.remove(it.cursor-1-it.removed, it.elements[it.cursor-1])
+	it.removed++
+	it.last = false
+	it.expected = it.mods()
+}
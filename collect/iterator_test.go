@@ -0,0 +1,174 @@
+package collect
+
+import (
+	"errors"
+	"testing"
+)
+
+func expectConcurrentModification(t *testing.T, do func()) {
+	t.Helper()
+	defer func() {
+		err, ok := recover().(error)
+		var target *ConcurrentModificationError
+		if !ok || !errors.As(err, &target) {
+			t.Errorf("expected error, got=%v", err)
+		}
+	}()
+	do()
+}
+
+func TestArrayList_ForEachModification(t *testing.T) {
+	list := NewList(1, 2, 3)
+	expectConcurrentModification(t, func() {
+		list.ForEach(func(el int) {
+			if el == 2 {
+				list.Remove(el)
+			}
+		})
+	})
+
+	set := NewSet(1, 2, 3)
+	expectConcurrentModification(t, func() {
+		set.ForEach(func(el int) {
+			set.Add(el + 10)
+		})
+	})
+}
+
+func TestArrayList_IterRemove(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	for it := list.Iter(); it.HasNext(); {
+		if it.Next()%2 == 0 {
+			it.Remove()
+		}
+	}
+	if !list.Equal(NewList(1, 3, 5)) {
+		t.Errorf("expected error, list=%s", list)
+	}
+
+	it := list.Iter()
+	it.Next()
+	list.Add(7)
+	expectConcurrentModification(t, func() {
+		it.Next()
+	})
+}
+
+func TestHashSet_IterRemove(t *testing.T) {
+	set := NewSet(1, 2, 3, 4)
+	for it := set.Iter(); it.HasNext(); {
+		if it.Next() > 2 {
+			it.Remove()
+		}
+	}
+	if !set.Equal(NewSet(1, 2)) {
+		t.Errorf("expected error, set=%s", set)
+	}
+
+	it := set.Iter()
+	set.Remove(1)
+	expectConcurrentModification(t, func() {
+		it.Next()
+	})
+}
+
+func TestRingBuffer_IterRemove(t *testing.T) {
+	buffer := NewRingBuffer[int](3, Overwrite)
+	buffer.AddAllSlice([]int{1, 2, 3, 4})
+	for it := buffer.Iter(); it.HasNext(); {
+		if it.Next() == 3 {
+			it.Remove()
+		}
+	}
+	if got := buffer.Slice(); len(got) != 2 || got[0] != 2 || got[1] != 4 {
+		t.Errorf("expected error, buffer=%s", buffer)
+	}
+
+	expectConcurrentModification(t, func() {
+		buffer.ForEach(func(el int) {
+			buffer.Offer(el)
+		})
+	})
+}
+
+func TestHashMultiset_IterRemove(t *testing.T) {
+	set := NewMultiset(1, 1, 2)
+	for it := set.Iter(); it.HasNext(); {
+		if it.Next() == 1 {
+			it.Remove()
+		}
+	}
+	if set.Count(1) != 0 || set.Count(2) != 1 {
+		t.Errorf("expected error, set=%s", set)
+	}
+
+	expectConcurrentModification(t, func() {
+		set.ForEach(func(el int) {
+			set.Add(el)
+		})
+	})
+}
+
+func TestEquivalenceSet_IterRemove(t *testing.T) {
+	set := NewSetWith(FoldCaseEquivalence(), "Go", "Rust", "Zig")
+	for it := set.Iter(); it.HasNext(); {
+		if it.Next() == "Rust" {
+			it.Remove()
+		}
+	}
+	if set.Size() != 2 || set.Contains("rust") {
+		t.Errorf("expected error, set=%s", set)
+	}
+
+	it := set.Iter()
+	set.Add("C")
+	expectConcurrentModification(t, func() {
+		it.Next()
+	})
+}
+
+func TestBiMap_ForEachModification(t *testing.T) {
+	m := NewBiMapOf(map[int]string{1: "a", 2: "b"})
+	expectConcurrentModification(t, func() {
+		m.ForEach(func(key int, value string) {
+			m.Inverse().Remove(value)
+		})
+	})
+
+	m = NewBiMapOf(map[int]string{1: "a", 2: "b"})
+	m.ForEach(func(key int, value string) {
+		m.ForcePut(key, value)
+	})
+	expectConcurrentModification(t, func() {
+		m.KeySet().ForEach(func(key int) {
+			m.Put(key+10, "x")
+		})
+	})
+}
+
+func TestIntervalTree_ForEachModification(t *testing.T) {
+	tree := NewIntervalTree[int, string]()
+	tree.Insert(1, 5, "a")
+	tree.Insert(3, 8, "b")
+	expectConcurrentModification(t, func() {
+		tree.ForEach(func(interval Interval[int, string]) {
+			tree.Delete(interval.Lo, interval.Hi)
+		})
+	})
+}
+
+func TestRingBuffer_IterRemoveDuplicate(t *testing.T) {
+	buffer := NewRingBuffer[int](4, Overwrite)
+	buffer.AddAllSlice([]int{9, 1, 2, 1, 3})
+	it := buffer.Iter()
+	for i := 0; i < 3; i++ {
+		it.Next()
+	}
+	it.Remove()
+	if got := buffer.Slice(); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("expected error, buffer=%s", buffer)
+	}
+	if el := it.Next(); el != 3 {
+		t.Errorf("expected error, element=%d, got=%d", 3, el)
+	}
+}
@@ -0,0 +1,316 @@
+package collect
+
+import (
+	"fmt"
+	"strings"
+)
+
+type MultimapEntry[K comparable, V comparable] struct {
+	Key   K
+	Value V
+}
+
+type Multimap[K comparable, V comparable] interface {
+	Put(key K, value V) bool
+	PutAll(key K, values Collection[V]) bool
+	Get(key K) Collection[V]
+	Remove(key K, value V) bool
+	RemoveAll(key K) Collection[V]
+
+	ContainsKey(key K) bool
+	ContainsValue(value V) bool
+	ContainsEntry(key K, value V) bool
+
+	Keys() *HashMultiset[K]
+	KeySet() Set[K]
+	Entries() *ArrayList[MultimapEntry[K, V]]
+	AsMap() map[K]Collection[V]
+
+	Size() int
+	IsEmpty() bool
+	Clear()
+	ForEach(do func(K, V))
+	String() string
+}
+
+type ListMultimap[K comparable, V comparable] struct {
+	multimap[K, V]
+}
+
+func NewListMultimap[K comparable, V comparable]() *ListMultimap[K, V] {
+	return &ListMultimap[K, V]{multimap[K, V]{
+		data: make(map[K]Collection[V]),
+		create: func() Collection[V] {
+			return NewList[V]()
+		},
+	}}
+}
+
+type SetMultimap[K comparable, V comparable] struct {
+	multimap[K, V]
+}
+
+func NewSetMultimap[K comparable, V comparable]() *SetMultimap[K, V] {
+	return &SetMultimap[K, V]{multimap[K, V]{
+		data: make(map[K]Collection[V]),
+		create: func() Collection[V] {
+			return NewSet[V]()
+		},
+	}}
+}
+
+func GroupBy[K comparable, V comparable](collection Collection[V], keyFn func(V) K) *ListMultimap[K, V] {
+	result := NewListMultimap[K, V]()
+	for el := range collection.Iterator() {
+		result.Put(keyFn(el), el)
+	}
+	return result
+}
+
+type multimap[K comparable, V comparable] struct {
+	data   map[K]Collection[V]
+	size   int
+	create func() Collection[V]
+}
+
+func (m *multimap[K, V]) Put(key K, value V) bool {
+	values, ok := m.data[key]
+	if !ok {
+		values = m.create()
+		m.data[key] = values
+	}
+	before := values.Size()
+	values.Add(value)
+	m.size += values.Size() - before
+	return values.Size() != before
+}
+
+func (m *multimap[K, V]) PutAll(key K, values Collection[V]) bool {
+	modified := false
+	for el := range values.Iterator() {
+		if m.Put(key, el) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (m *multimap[K, V]) Get(key K) Collection[V] {
+	return &multimapValues[K, V]{m: m, key: key}
+}
+
+func (m *multimap[K, V]) Remove(key K, value V) bool {
+	return m.Get(key).Remove(value)
+}
+
+func (m *multimap[K, V]) RemoveAll(key K) Collection[V] {
+	values, ok := m.data[key]
+	if !ok {
+		return m.create()
+	}
+	delete(m.data, key)
+	m.size -= values.Size()
+	return values
+}
+
+func (m *multimap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.data[key]
+	return ok
+}
+
+func (m *multimap[K, V]) ContainsValue(value V) bool {
+	for _, values := range m.data {
+		if values.Contains(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *multimap[K, V]) ContainsEntry(key K, value V) bool {
+	values, ok := m.data[key]
+	return ok && values.Contains(value)
+}
+
+func (m *multimap[K, V]) Keys() *HashMultiset[K] {
+	keys := NewMultiset[K]()
+	for key, values := range m.data {
+		keys.SetCount(key, values.Size())
+	}
+	return keys
+}
+
+func (m *multimap[K, V]) KeySet() Set[K] {
+	keys := NewSet[K]()
+	for key := range m.data {
+		keys.Add(key)
+	}
+	return keys
+}
+
+func (m *multimap[K, V]) Entries() *ArrayList[MultimapEntry[K, V]] {
+	entries := make([]MultimapEntry[K, V], 0, m.size)
+	m.ForEach(func(key K, value V) {
+		entries = append(entries, MultimapEntry[K, V]{Key: key, Value: value})
+	})
+	return NewList(entries...)
+}
+
+func (m *multimap[K, V]) AsMap() map[K]Collection[V] {
+	result := make(map[K]Collection[V], len(m.data))
+	for key := range m.data {
+		result[key] = m.Get(key)
+	}
+	return result
+}
+
+func (m *multimap[K, V]) Size() int {
+	return m.size
+}
+
+func (m *multimap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+func (m *multimap[K, V]) Clear() {
+	m.data = make(map[K]Collection[V])
+	m.size = 0
+}
+
+func (m *multimap[K, V]) ForEach(do func(K, V)) {
+	for key, values := range m.data {
+		values.ForEach(func(value V) {
+			do(key, value)
+		})
+	}
+}
+
+func (m *multimap[K, V]) String() string {
+	var data []string
+	for key, values := range m.data {
+		data = append(data, fmt.Sprintf("%v:%s", key, values))
+	}
+	return "{" + strings.Join(data, " ") + "}"
+}
+
+type multimapValues[K comparable, V comparable] struct {
+	m   *multimap[K, V]
+	key K
+}
+
+func (v *multimapValues[K, V]) Add(element V) {
+	v.m.Put(v.key, element)
+}
+
+func (v *multimapValues[K, V]) AddAll(elements Collection[V]) {
+	for el := range elements.Iterator() {
+		v.Add(el)
+	}
+}
+
+func (v *multimapValues[K, V]) AddAllSlice(elements []V) {
+	for _, el := range elements {
+		v.Add(el)
+	}
+}
+
+func (v *multimapValues[K, V]) Contains(element V) bool {
+	values, ok := v.m.data[v.key]
+	return ok && values.Contains(element)
+}
+
+func (v *multimapValues[K, V]) ContainsAll(elements Collection[V]) bool {
+	for el := range elements.Iterator() {
+		if !v.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *multimapValues[K, V]) ContainsAllSlice(elements []V) bool {
+	for _, el := range elements {
+		if !v.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *multimapValues[K, V]) Remove(element V) bool {
+	return v.modify(func(values Collection[V]) {
+		values.Remove(element)
+	})
+}
+
+func (v *multimapValues[K, V]) RemoveAll(elements Collection[V]) bool {
+	return v.modify(func(values Collection[V]) {
+		values.RemoveAll(elements)
+	})
+}
+
+func (v *multimapValues[K, V]) RemoveAllSlice(elements []V) bool {
+	return v.modify(func(values Collection[V]) {
+		values.RemoveAllSlice(elements)
+	})
+}
+
+func (v *multimapValues[K, V]) RemoveIf(predicate func(V) bool) bool {
+	return v.modify(func(values Collection[V]) {
+		values.RemoveIf(predicate)
+	})
+}
+
+func (v *multimapValues[K, V]) Size() int {
+	values, ok := v.m.data[v.key]
+	if !ok {
+		return 0
+	}
+	return values.Size()
+}
+
+func (v *multimapValues[K, V]) IsEmpty() bool {
+	return v.Size() == 0
+}
+
+func (v *multimapValues[K, V]) Clear() {
+	v.m.RemoveAll(v.key)
+}
+
+func (v *multimapValues[K, V]) Iterator() <-chan V {
+	values, ok := v.m.data[v.key]
+	if !ok {
+		pool := make(chan V)
+		close(pool)
+		return pool
+	}
+	return values.Iterator()
+}
+
+func (v *multimapValues[K, V]) ForEach(do func(V)) {
+	if values, ok := v.m.data[v.key]; ok {
+		values.ForEach(do)
+	}
+}
+
+func (v *multimapValues[K, V]) String() string {
+	values, ok := v.m.data[v.key]
+	if !ok {
+		return "[]"
+	}
+	return values.String()
+}
+
+func (v *multimapValues[K, V]) modify(do func(values Collection[V])) bool {
+	values, ok := v.m.data[v.key]
+	if !ok {
+		return false
+	}
+	before := values.Size()
+	do(values)
+	v.m.size += values.Size() - before
+	if values.IsEmpty() {
+		delete(v.m.data, v.key)
+	}
+	return values.Size() != before
+}
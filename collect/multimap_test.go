@@ -0,0 +1,58 @@
+package collect
+
+import "testing"
+
+func TestListMultimap_LiveView(t *testing.T) {
+	multimap := NewListMultimap[string, int]()
+	view := multimap.Get("a")
+	if !view.IsEmpty() || multimap.ContainsKey("a") {
+		t.Errorf("expected error, view not empty %s", view)
+	}
+
+	view.Add(1)
+	view.Add(1)
+	multimap.Put("b", 2)
+	if multimap.Size() != 3 || multimap.Get("a").Size() != 2 {
+		t.Errorf("expected error, size=%d, got=%d", 3, multimap.Size())
+	}
+
+	view.Remove(1)
+	view.Remove(1)
+	if multimap.ContainsKey("a") || multimap.Size() != 1 {
+		t.Errorf("expected error, key not deleted %s", multimap)
+	}
+}
+
+func TestSetMultimap_Put(t *testing.T) {
+	multimap := NewSetMultimap[string, int]()
+	if !multimap.Put("a", 1) || multimap.Put("a", 1) {
+		t.Errorf("expected error, duplicate value stored %s", multimap)
+	}
+	multimap.PutAll("a", NewList(2, 3))
+	multimap.Put("b", 1)
+
+	keys := multimap.Keys()
+	if keys.Count("a") != 3 || keys.Count("b") != 1 {
+		t.Errorf("expected error, incorrect keys %s", keys)
+	}
+	if multimap.Entries().Size() != 4 || !multimap.ContainsEntry("b", 1) || !multimap.ContainsValue(3) {
+		t.Errorf("expected error, incorrect entries %s", multimap)
+	}
+
+	removed := multimap.RemoveAll("a")
+	if removed.Size() != 3 || multimap.Size() != 1 {
+		t.Errorf("expected error, incorrect RemoveAll %s", removed)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	grouped := GroupBy[bool, int](NewList(1, 2, 3, 4, 5), func(i int) bool {
+		return i%2 == 0
+	})
+	if grouped.Get(true).Size() != 2 || grouped.Get(false).Size() != 3 {
+		t.Errorf("expected error, incorrect groups %s", grouped)
+	}
+	if len(grouped.AsMap()) != 2 {
+		t.Errorf("expected error, incorrect map %v", grouped.AsMap())
+	}
+}
@@ -0,0 +1,355 @@
+package collect
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type MultisetEntry[T comparable] struct {
+	Element T
+	Count   int
+}
+
+type Multiset[T comparable] interface {
+	Count(element T) int
+	SetCount(element T, count int) int
+	AddOccurrences(element T, n int) int
+	RemoveOccurrences(element T, n int) int
+	ElementSet() Set[T]
+	EntrySet() Set[MultisetEntry[T]]
+	Collection[T]
+}
+
+type HashMultiset[T comparable] struct {
+	data map[T]int
+	size int
+	mods int
+}
+
+func NewMultiset[T comparable](elements ...T) *HashMultiset[T] {
+	multiset := &HashMultiset[T]{data: make(map[T]int)}
+	multiset.AddAllSlice(elements)
+	return multiset
+}
+
+func NewMultisetOf[T comparable](elements Collection[T]) *HashMultiset[T] {
+	multiset := &HashMultiset[T]{data: make(map[T]int)}
+	multiset.AddAll(elements)
+	return multiset
+}
+
+func (m *HashMultiset[T]) Count(element T) int {
+	return m.data[element]
+}
+
+func (m *HashMultiset[T]) SetCount(element T, count int) int {
+	if count < 0 {
+		panic(fmt.Sprintf("collect: negative count %d", count))
+	}
+	old := m.data[element]
+	if count == 0 {
+		delete(m.data, element)
+	} else {
+		m.data[element] = count
+	}
+	m.size += count - old
+	if count != old {
+		m.mods++
+	}
+	return old
+}
+
+func (m *HashMultiset[T]) AddOccurrences(element T, n int) int {
+	if n < 0 {
+		panic(fmt.Sprintf("collect: negative occurrences %d", n))
+	}
+	return m.SetCount(element, m.data[element]+n)
+}
+
+func (m *HashMultiset[T]) RemoveOccurrences(element T, n int) int {
+	if n < 0 {
+		panic(fmt.Sprintf("collect: negative occurrences %d", n))
+	}
+	old := m.data[element]
+	if n > old {
+		n = old
+	}
+	return m.SetCount(element, old-n)
+}
+
+func (m *HashMultiset[T]) DistinctSize() int {
+	return len(m.data)
+}
+
+func (m *HashMultiset[T]) ElementSet() Set[T] {
+	set := NewSet[T]()
+	for el := range m.data {
+		set.Add(el)
+	}
+	return set
+}
+
+func (m *HashMultiset[T]) EntrySet() Set[MultisetEntry[T]] {
+	set := NewSet[MultisetEntry[T]]()
+	for el, count := range m.data {
+		set.Add(MultisetEntry[T]{Element: el, Count: count})
+	}
+	return set
+}
+
+// MostCommon returns the k entries with the highest counts, highest first, or
+// all of them if k is negative. Entries with equal counts come in no
+// particular order, so which of them make the cut at k is unspecified.
+func (m *HashMultiset[T]) MostCommon(k int) *ArrayList[MultisetEntry[T]] {
+	entries := m.entries()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Count > entries[j].Count
+	})
+	if k >= 0 && k < len(entries) {
+		entries = entries[:k]
+	}
+	return NewList(entries...)
+}
+
+func (m *HashMultiset[T]) Union(other *HashMultiset[T]) *HashMultiset[T] {
+	result := m.copy()
+	for el, count := range other.data {
+		if count > result.data[el] {
+			result.SetCount(el, count)
+		}
+	}
+	return result
+}
+
+func (m *HashMultiset[T]) Intersect(other *HashMultiset[T]) *HashMultiset[T] {
+	result := NewMultiset[T]()
+	for el, count := range m.data {
+		if otherCount := other.data[el]; otherCount < count {
+			result.SetCount(el, otherCount)
+		} else {
+			result.SetCount(el, count)
+		}
+	}
+	return result
+}
+
+func (m *HashMultiset[T]) Sum(other *HashMultiset[T]) *HashMultiset[T] {
+	result := m.copy()
+	for el, count := range other.data {
+		result.AddOccurrences(el, count)
+	}
+	return result
+}
+
+func (m *HashMultiset[T]) Subtract(other *HashMultiset[T]) *HashMultiset[T] {
+	result := m.copy()
+	for el, count := range other.data {
+		result.RemoveOccurrences(el, count)
+	}
+	return result
+}
+
+func (m *HashMultiset[T]) Equal(other *HashMultiset[T]) bool {
+	if other == nil {
+		return false
+	}
+	if m.size != other.size || len(m.data) != len(other.data) {
+		return false
+	}
+
+	for el, count := range m.data {
+		if other.data[el] != count {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *HashMultiset[T]) Add(element T) {
+	m.AddOccurrences(element, 1)
+}
+
+func (m *HashMultiset[T]) AddAll(elements Collection[T]) {
+	for el := range elements.Iterator() {
+		m.Add(el)
+	}
+}
+
+func (m *HashMultiset[T]) AddAllSlice(elements []T) {
+	for _, el := range elements {
+		m.Add(el)
+	}
+}
+
+func (m *HashMultiset[T]) Contains(element T) bool {
+	_, ok := m.data[element]
+	return ok
+}
+
+func (m *HashMultiset[T]) ContainsAll(elements Collection[T]) bool {
+	for el := range elements.Iterator() {
+		if !m.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *HashMultiset[T]) ContainsAllSlice(elements []T) bool {
+	for _, el := range elements {
+		if !m.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *HashMultiset[T]) Remove(element T) bool {
+	return m.RemoveOccurrences(element, 1) > 0
+}
+
+func (m *HashMultiset[T]) RemoveAll(elements Collection[T]) bool {
+	modified := false
+	for el := range elements.Iterator() {
+		if m.SetCount(el, 0) > 0 {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (m *HashMultiset[T]) RemoveAllSlice(elements []T) bool {
+	modified := false
+	for _, el := range elements {
+		if m.SetCount(el, 0) > 0 {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (m *HashMultiset[T]) RemoveIf(predicate func(T) bool) bool {
+	modified := false
+	for el := range m.data {
+		if predicate(el) {
+			m.SetCount(el, 0)
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (m *HashMultiset[T]) Size() int {
+	return m.size
+}
+
+func (m *HashMultiset[T]) IsEmpty() bool {
+	return m.size == 0
+}
+
+func (m *HashMultiset[T]) Clear() {
+	m.data = make(map[T]int)
+	m.size = 0
+	m.mods++
+}
+
+// Iterator sends every occurrence from a goroutine that reads a snapshot of
+// the distinct elements, so it does not allocate per occurrence; the channel
+// must be drained for the goroutine to exit.
+func (m *HashMultiset[T]) Iterator() <-chan T {
+	entries := m.entries()
+	pool := make(chan T, len(entries))
+	go func() {
+		defer close(pool)
+		for _, entry := range entries {
+			for i := 0; i < entry.Count; i++ {
+				pool <- entry.Element
+			}
+		}
+	}()
+	return pool
+}
+
+func (m *HashMultiset[T]) ForEach(do func(T)) {
+	mods := m.mods
+	for el, count := range m.data {
+		for i := 0; i < count; i++ {
+			do(el)
+			checkModifications(mods, m.mods)
+		}
+	}
+}
+
+// Iter visits every occurrence; Remove drops the current occurrence only.
+func (m *HashMultiset[T]) Iter() Iterator[T] {
+	return &multisetIterator[T]{multiset: m, entries: m.entries(), expected: m.mods}
+}
+
+func (m *HashMultiset[T]) entries() []MultisetEntry[T] {
+	entries := make([]MultisetEntry[T], 0, len(m.data))
+	for el, count := range m.data {
+		entries = append(entries, MultisetEntry[T]{Element: el, Count: count})
+	}
+	return entries
+}
+
+func (m *HashMultiset[T]) String() string {
+	var data []string
+	for el, count := range m.data {
+		data = append(data, fmt.Sprintf("%v:%d", el, count))
+	}
+	return "[" + strings.Join(data, " ") + "]"
+}
+
+func (m *HashMultiset[T]) copy() *HashMultiset[T] {
+	result := &HashMultiset[T]{data: make(map[T]int, len(m.data)), size: m.size}
+	for el, count := range m.data {
+		result.data[el] = count
+	}
+	return result
+}
+
+// multisetIterator walks the entries of a multiset and repeats each element
+// Count times, so it needs no slot per occurrence.
+type multisetIterator[T comparable] struct {
+	multiset   *HashMultiset[T]
+	entries    []MultisetEntry[T]
+	entry      int
+	occurrence int
+	expected   int
+	last       bool
+}
+
+func (it *multisetIterator[T]) HasNext() bool {
+	return it.entry < len(it.entries)
+}
+
+func (it *multisetIterator[T]) Next() T {
+	checkModifications(it.expected, it.multiset.mods)
+	if it.entry >= len(it.entries) {
+		panic("collect: Next on exhausted Iterator")
+	}
+	el := it.entries[it.entry].Element
+	it.occurrence++
+	if it.occurrence == it.entries[it.entry].Count {
+		it.entry++
+		it.occurrence = 0
+	}
+	it.last = true
+	return el
+}
+
+func (it *multisetIterator[T]) Remove() {
+	checkModifications(it.expected, it.multiset.mods)
+	if !it.last {
+		panic("collect: Remove without Next")
+	}
+	entry := it.entry
+	if it.occurrence == 0 {
+		entry--
+	}
+	it.multiset.RemoveOccurrences(it.entries[entry].Element, 1)
+	it.last = false
+	it.expected = it.multiset.mods
+}
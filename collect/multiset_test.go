@@ -0,0 +1,85 @@
+package collect
+
+import "testing"
+
+func TestHashMultiset_Counts(t *testing.T) {
+	multiset := NewMultiset("a", "b", "a", "c", "a")
+	if multiset.Size() != 5 || multiset.DistinctSize() != 3 {
+		t.Errorf("expected error, size=%d/%d, got=%d/%d", 5, 3, multiset.Size(), multiset.DistinctSize())
+	}
+	if multiset.Count("a") != 3 {
+		t.Errorf("expected error, count=%d, got=%d", 3, multiset.Count("a"))
+	}
+
+	if old := multiset.RemoveOccurrences("a", 2); old != 3 || multiset.Count("a") != 1 {
+		t.Errorf("expected error, incorrect count after RemoveOccurrences %s", multiset)
+	}
+	if old := multiset.RemoveOccurrences("b", 10); old != 1 || multiset.Contains("b") {
+		t.Errorf("expected error, not deleted %s", "b")
+	}
+	multiset.SetCount("d", 4)
+	if multiset.Size() != 6 {
+		t.Errorf("expected error, size=%d, got=%d", 6, multiset.Size())
+	}
+
+	n := 0
+	multiset.ForEach(func(string) { n++ })
+	if n != multiset.Size() {
+		t.Errorf("expected error, iterated=%d, got=%d", multiset.Size(), n)
+	}
+}
+
+func TestHashMultiset_MostCommon(t *testing.T) {
+	multiset := NewMultiset(1, 2, 2, 3, 3, 3)
+	top := multiset.MostCommon(2)
+	if top.Size() != 2 || top.Get(0) != (MultisetEntry[int]{3, 3}) || top.Get(1) != (MultisetEntry[int]{2, 2}) {
+		t.Errorf("expected error, incorrect most common %s", top)
+	}
+	if multiset.MostCommon(-1).Size() != 3 {
+		t.Errorf("expected error, incorrect most common size")
+	}
+
+	tied := NewMultiset("a", "b", "b", "c", "c", "d").MostCommon(2)
+	if tied.Size() != 2 || tied.Get(0).Count != 2 || tied.Get(1).Count != 2 || tied.Get(0) == tied.Get(1) {
+		t.Errorf("expected error, incorrect tied most common %s", tied)
+	}
+}
+
+func TestHashMultiset_IterLargeCount(t *testing.T) {
+	multiset := NewMultiset("a")
+	multiset.SetCount("b", 1<<40)
+	it := multiset.Iter()
+	seen := map[string]int{}
+	for i := 0; i < 3; i++ {
+		el := it.Next()
+		seen[el]++
+		if el == "b" {
+			it.Remove()
+		}
+	}
+	if seen["a"]+seen["b"] != 3 || multiset.Count("b") != 1<<40-seen["b"] || multiset.Count("a") != 1 {
+		t.Errorf("expected error, seen=%v, counts a=%d b=%d", seen, multiset.Count("a"), multiset.Count("b"))
+	}
+}
+
+func TestHashMultiset_SetOperations(t *testing.T) {
+	a := NewMultiset("x", "x", "y")
+	b := NewMultiset("x", "y", "y", "z")
+
+	tests := []struct {
+		name   string
+		result *HashMultiset[string]
+		check  *HashMultiset[string]
+	}{
+		{name: "union", result: a.Union(b), check: NewMultiset("x", "x", "y", "y", "z")},
+		{name: "intersect", result: a.Intersect(b), check: NewMultiset("x", "y")},
+		{name: "sum", result: a.Sum(b), check: NewMultiset("x", "x", "x", "y", "y", "y", "z")},
+		{name: "subtract", result: a.Subtract(b), check: NewMultiset("x")},
+	}
+
+	for _, test := range tests {
+		if !test.result.Equal(test.check) {
+			t.Errorf("expected error, %s=%s, got=%s", test.name, test.check, test.result)
+		}
+	}
+}
@@ -0,0 +1,514 @@
+package collect
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota
+	Removed
+	Cleared
+	Replaced
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "Added"
+	case Removed:
+		return "Removed"
+	case Cleared:
+		return "Cleared"
+	case Replaced:
+		return "Replaced"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change describes a single modification. Index is the element's position at
+// the time of the change for lists and queues, and -1 otherwise. Old is only
+// set for Replaced.
+type Change[T any] struct {
+	Kind    ChangeKind
+	Index   int
+	Element T
+	Old     T
+}
+
+// ChangeEvent holds every change made by one call, so AddAll, RemoveAll and
+// RemoveIf publish a single event.
+type ChangeEvent[T any] struct {
+	Changes []Change[T]
+}
+
+// ObservableCollection publishes a ChangeEvent to its subscribers after every
+// modification made through it. Modifications and reads are guarded by the
+// observable itself, so any collection can be shared between goroutines once
+// wrapped. Events are delivered in modification order; subscribers must not
+// modify the observable or subscribe from inside a callback, but they may
+// cancel a subscription there.
+type ObservableCollection[T comparable] struct {
+	observable[T]
+}
+
+func Observable[T comparable](collection Collection[T]) *ObservableCollection[T] {
+	_, list := collection.(List[T])
+	_, queue := collection.(Queue[T])
+	return &ObservableCollection[T]{newObservable(collection, list || queue)}
+}
+
+type ObservableList[T comparable] struct {
+	observable[T]
+	list List[T]
+}
+
+func ObserveList[T comparable](list interface {
+	Collection[T]
+	List[T]
+}) *ObservableList[T] {
+	return &ObservableList[T]{observable: newObservable[T](list, true), list: list}
+}
+
+func (l *ObservableList[T]) Get(index int) T {
+	l.mx.RLock()
+	defer l.mx.RUnlock()
+	return l.list.Get(index)
+}
+
+func (l *ObservableList[T]) SafeGet(index int) (T, bool) {
+	l.mx.RLock()
+	defer l.mx.RUnlock()
+	return l.list.SafeGet(index)
+}
+
+func (l *ObservableList[T]) IndexOf(element T) int {
+	l.mx.RLock()
+	defer l.mx.RUnlock()
+	index, _ := l.find(element)
+	return index
+}
+
+// Slice returns a copy of the elements, so that every modification goes
+// through the observable.
+func (l *ObservableList[T]) Slice() *[]T {
+	l.mx.RLock()
+	defer l.mx.RUnlock()
+	data := make([]T, 0, l.data.Size())
+	for el := range l.data.Iterator() {
+		data = append(data, el)
+	}
+	return &data
+}
+
+// Set replaces the element at index and returns the old one. It writes
+// through the list's Slice, which copies data shared with a snapshot first.
+func (l *ObservableList[T]) Set(index int, element T) T {
+	var old T
+	l.modify(func(record func(Change[T])) {
+		data := l.list.Slice()
+		old = (*data)[index]
+		(*data)[index] = element
+		record(Change[T]{Kind: Replaced, Index: index, Element: element, Old: old})
+	})
+	return old
+}
+
+type ObservableSet[T comparable] struct {
+	observable[T]
+	set Set[T]
+}
+
+func ObserveSet[T comparable](set Set[T]) *ObservableSet[T] {
+	return &ObservableSet[T]{observable: newObservable[T](set, false), set: set}
+}
+
+func (s *ObservableSet[T]) Equal(elements Set[T]) bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.set.Equal(elements)
+}
+
+type ObservableQueue[T comparable] struct {
+	observable[T]
+	queue Queue[T]
+}
+
+func ObserveQueue[T comparable](queue interface {
+	Collection[T]
+	Queue[T]
+}) *ObservableQueue[T] {
+	return &ObservableQueue[T]{observable: newObservable[T](queue, true), queue: queue}
+}
+
+func (q *ObservableQueue[T]) Offer(element T) {
+	q.modify(func(record func(Change[T])) {
+		index := q.data.Size()
+		q.queue.Offer(element)
+		record(Change[T]{Kind: Added, Index: index, Element: element})
+	})
+}
+
+func (q *ObservableQueue[T]) Pool() T {
+	var result T
+	q.modify(func(record func(Change[T])) {
+		result = q.queue.Pool()
+		record(Change[T]{Kind: Removed, Index: 0, Element: result})
+	})
+	return result
+}
+
+func (q *ObservableQueue[T]) Peek() T {
+	q.mx.RLock()
+	defer q.mx.RUnlock()
+	return q.queue.Peek()
+}
+
+type subscriber[T any] struct {
+	id        int
+	do        func(ChangeEvent[T])
+	ch        chan ChangeEvent[T]
+	done      chan struct{}
+	cancelled *atomic.Bool
+}
+
+// observable guards data with mx and delivers events under pub, which is
+// taken before mx is released so that events keep the modification order
+// while callbacks are still free to read the collection. delivering is set
+// while pub is held to call subscribers.
+type observable[T comparable] struct {
+	mx          *sync.RWMutex
+	pub         *sync.Mutex
+	delivering  *atomic.Bool
+	data        Collection[T]
+	ordered     bool
+	subscribers []subscriber[T]
+	nextID      int
+}
+
+func newObservable[T comparable](data Collection[T], ordered bool) observable[T] {
+	return observable[T]{
+		mx:         &sync.RWMutex{},
+		pub:        &sync.Mutex{},
+		delivering: &atomic.Bool{},
+		data:       data,
+		ordered:    ordered,
+	}
+}
+
+// Subscribe registers do to be called with every event and returns a function
+// that cancels the subscription.
+func (o *observable[T]) Subscribe(do func(ChangeEvent[T])) func() {
+	return o.subscribe(subscriber[T]{do: do, cancelled: &atomic.Bool{}})
+}
+
+// SubscribeChan returns a channel receiving every event and a function that
+// cancels the subscription and closes the channel. Publishing waits while the
+// channel buffer is full, until the subscription is cancelled.
+func (o *observable[T]) SubscribeChan(buffer int) (<-chan ChangeEvent[T], func()) {
+	ch := make(chan ChangeEvent[T], buffer)
+	return ch, o.subscribe(subscriber[T]{ch: ch, done: make(chan struct{}), cancelled: &atomic.Bool{}})
+}
+
+func (o *observable[T]) Add(element T) {
+	o.modify(func(record func(Change[T])) {
+		o.add(element, record)
+	})
+}
+
+func (o *observable[T]) AddAll(elements Collection[T]) {
+	pool := elements.Iterator()
+	o.modify(func(record func(Change[T])) {
+		for el := range pool {
+			o.add(el, record)
+		}
+	})
+}
+
+func (o *observable[T]) AddAllSlice(elements []T) {
+	o.modify(func(record func(Change[T])) {
+		for _, el := range elements {
+			o.add(el, record)
+		}
+	})
+}
+
+func (o *observable[T]) Contains(element T) bool {
+	o.mx.RLock()
+	defer o.mx.RUnlock()
+	return o.data.Contains(element)
+}
+
+func (o *observable[T]) ContainsAll(elements Collection[T]) bool {
+	for el := range elements.Iterator() {
+		if !o.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *observable[T]) ContainsAllSlice(elements []T) bool {
+	for _, el := range elements {
+		if !o.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *observable[T]) Remove(element T) bool {
+	return o.modify(func(record func(Change[T])) {
+		o.remove(element, record)
+	})
+}
+
+func (o *observable[T]) RemoveAll(elements Collection[T]) bool {
+	pool := elements.Iterator()
+	return o.modify(func(record func(Change[T])) {
+		var data []T
+		for el := range pool {
+			data = append(data, el)
+		}
+		o.removeAll(data, record)
+	})
+}
+
+func (o *observable[T]) RemoveAllSlice(elements []T) bool {
+	return o.modify(func(record func(Change[T])) {
+		o.removeAll(elements, record)
+	})
+}
+
+func (o *observable[T]) RemoveIf(predicate func(T) bool) bool {
+	return o.modify(func(record func(Change[T])) {
+		if o.ordered {
+			o.removeOrdered(predicate, record)
+			return
+		}
+		for el := range o.data.Iterator() {
+			if predicate(el) {
+				o.remove(el, record)
+			}
+		}
+	})
+}
+
+func (o *observable[T]) Size() int {
+	o.mx.RLock()
+	defer o.mx.RUnlock()
+	return o.data.Size()
+}
+
+func (o *observable[T]) IsEmpty() bool {
+	o.mx.RLock()
+	defer o.mx.RUnlock()
+	return o.data.IsEmpty()
+}
+
+func (o *observable[T]) Clear() {
+	o.modify(func(record func(Change[T])) {
+		if !o.data.IsEmpty() {
+			o.data.Clear()
+			record(Change[T]{Kind: Cleared, Index: -1})
+		}
+	})
+}
+
+func (o *observable[T]) Iterator() <-chan T {
+	o.mx.RLock()
+	defer o.mx.RUnlock()
+	return o.data.Iterator()
+}
+
+func (o *observable[T]) ForEach(do func(T)) {
+	for el := range o.Iterator() {
+		do(el)
+	}
+}
+
+func (o *observable[T]) String() string {
+	o.mx.RLock()
+	defer o.mx.RUnlock()
+	return o.data.String()
+}
+
+func (o *observable[T]) subscribe(s subscriber[T]) func() {
+	o.pub.Lock()
+	defer o.pub.Unlock()
+	o.nextID++
+	s.id = o.nextID
+	o.subscribers = append(o.subscribers, s)
+
+	once := &sync.Once{}
+	return func() {
+		once.Do(func() {
+			s.cancelled.Store(true)
+			// Closing done first releases a publisher blocked on a full
+			// channel, which holds pub.
+			if s.done != nil {
+				close(s.done)
+			}
+			o.unsubscribe()
+		})
+	}
+}
+
+// unsubscribe drops the cancelled subscribers. While subscribers are being
+// called it leaves that to the publisher instead, since a callback cancelling
+// a subscription runs with pub already held.
+func (o *observable[T]) unsubscribe() {
+	if !o.pub.TryLock() {
+		if o.delivering.Load() {
+			return
+		}
+		o.pub.Lock()
+	}
+	defer o.pub.Unlock()
+	o.dropCancelled()
+}
+
+// dropCancelled removes the cancelled subscribers and closes their channels.
+// The caller must hold pub.
+func (o *observable[T]) dropCancelled() {
+	kept := o.subscribers[:0]
+	for _, s := range o.subscribers {
+		if !s.cancelled.Load() {
+			kept = append(kept, s)
+		} else if s.ch != nil {
+			close(s.ch)
+		}
+	}
+	o.subscribers = kept
+}
+
+// modify runs do under the write lock and publishes the changes it recorded
+// as one event. It reports whether anything changed.
+func (o *observable[T]) modify(do func(record func(Change[T]))) bool {
+	o.mx.Lock()
+	handedOver := false
+	defer func() {
+		if !handedOver {
+			o.mx.Unlock()
+		}
+	}()
+
+	var changes []Change[T]
+	do(func(change Change[T]) {
+		changes = append(changes, change)
+	})
+	if len(changes) == 0 {
+		return false
+	}
+
+	o.pub.Lock()
+	defer o.pub.Unlock()
+	handedOver = true
+	o.mx.Unlock()
+
+	event := ChangeEvent[T]{Changes: changes}
+	o.delivering.Store(true)
+	defer func() {
+		o.delivering.Store(false)
+		o.dropCancelled()
+	}()
+	for _, s := range o.subscribers {
+		if s.cancelled.Load() {
+			continue
+		}
+		if s.do != nil {
+			s.do(event)
+		} else {
+			select {
+			case s.ch <- event:
+			case <-s.done:
+			}
+		}
+	}
+	return true
+}
+
+func (o *observable[T]) add(element T, record func(Change[T])) {
+	before := o.data.Size()
+	o.data.Add(element)
+	if o.data.Size() != before {
+		index := -1
+		if o.ordered {
+			index = before
+		}
+		record(Change[T]{Kind: Added, Index: index, Element: element})
+	}
+}
+
+// remove checks for the element first, since not every collection reports
+// accurately whether Remove changed it.
+func (o *observable[T]) remove(element T, record func(Change[T])) {
+	if index, ok := o.find(element); ok {
+		o.data.Remove(element)
+		record(Change[T]{Kind: Removed, Index: index, Element: element})
+	}
+}
+
+// removeAll removes one occurrence of every element of elements. For ordered
+// collections the positions are found in a single pass instead of one per
+// element.
+func (o *observable[T]) removeAll(elements []T, record func(Change[T])) {
+	if !o.ordered {
+		for _, el := range elements {
+			o.remove(el, record)
+		}
+		return
+	}
+	wanted := make(map[T]int, len(elements))
+	for _, el := range elements {
+		wanted[el]++
+	}
+	o.removeOrdered(func(el T) bool {
+		if wanted[el] == 0 {
+			return false
+		}
+		wanted[el]--
+		return true
+	}, record)
+}
+
+// removeOrdered calls matches once per element from the front and removes the
+// matched ones, recording the position of each at the time of its removal.
+func (o *observable[T]) removeOrdered(matches func(T) bool, record func(Change[T])) {
+	var dropped []bool
+	removed := 0
+	for el := range o.data.Iterator() {
+		drop := matches(el)
+		if drop {
+			record(Change[T]{Kind: Removed, Index: len(dropped) - removed, Element: el})
+			removed++
+		}
+		dropped = append(dropped, drop)
+	}
+	if removed == 0 {
+		return
+	}
+	// Lists and queues visit their elements from the front in RemoveIf.
+	position := -1
+	o.data.RemoveIf(func(T) bool {
+		position++
+		return dropped[position]
+	})
+}
+
+func (o *observable[T]) find(element T) (int, bool) {
+	if !o.ordered {
+		return -1, o.data.Contains(element)
+	}
+	i := 0
+	for el := range o.data.Iterator() {
+		if el == element {
+			return i, true
+		}
+		i++
+	}
+	return -1, false
+}
@@ -0,0 +1,138 @@
+package collect
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestObservableList_Events(t *testing.T) {
+	list := ObserveList[int](NewList(1, 2, 3))
+	var events []ChangeEvent[int]
+	unsubscribe := list.Subscribe(func(event ChangeEvent[int]) {
+		events = append(events, event)
+	})
+
+	list.Add(4)
+	list.AddAllSlice([]int{5, 6})
+	list.Remove(2)
+	list.Set(0, 10)
+	list.Remove(42)
+	if len(events) != 4 {
+		t.Fatalf("expected error, events=%d, got=%d", 4, len(events))
+	}
+	if c := events[0].Changes[0]; c.Kind != Added || c.Index != 3 || c.Element != 4 {
+		t.Errorf("expected error, incorrect change %+v", c)
+	}
+	if len(events[1].Changes) != 2 || events[1].Changes[1].Index != 5 {
+		t.Errorf("expected error, AddAll not batched %+v", events[1])
+	}
+	if c := events[2].Changes[0]; c.Kind != Removed || c.Index != 1 || c.Element != 2 {
+		t.Errorf("expected error, incorrect change %+v", c)
+	}
+	if c := events[3].Changes[0]; c.Kind != Replaced || c.Old != 1 || c.Element != 10 {
+		t.Errorf("expected error, incorrect change %+v", c)
+	}
+
+	unsubscribe()
+	list.Clear()
+	if len(events) != 4 || !list.IsEmpty() {
+		t.Errorf("expected error, event after unsubscribe %+v", events)
+	}
+}
+
+func TestObservableSet_SubscribeChan(t *testing.T) {
+	set := ObserveSet[int](NewSet(1, 2))
+	events, unsubscribe := set.SubscribeChan(4)
+
+	set.Add(1)
+	set.RemoveAllSlice([]int{1, 2, 3})
+	set.Clear()
+	set.Add(5)
+	set.Clear()
+	unsubscribe()
+
+	var kinds []ChangeKind
+	for event := range events {
+		for _, c := range event.Changes {
+			if c.Index != -1 {
+				t.Errorf("expected error, index=%d, got=%d", -1, c.Index)
+			}
+			kinds = append(kinds, c.Kind)
+		}
+	}
+	if !NewList(kinds...).Equal(NewList(Removed, Removed, Added, Cleared)) {
+		t.Errorf("expected error, kinds=%v", kinds)
+	}
+}
+
+func TestObservable_CancelFullChan(t *testing.T) {
+	list := Observable[int](NewList[int]())
+	_, unsubscribe := list.SubscribeChan(1)
+	list.Add(1)
+
+	added := make(chan struct{})
+	go func() {
+		list.Add(2)
+		close(added)
+	}()
+	for deadline := time.Now().Add(time.Second); !list.delivering.Load(); runtime.Gosched() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected error, publisher not blocked")
+		}
+	}
+	unsubscribe()
+
+	select {
+	case <-added:
+	case <-time.After(time.Second):
+		t.Fatal("expected error, publisher still blocked after cancel")
+	}
+	if list.Size() != 2 {
+		t.Errorf("expected error, list=%s", list)
+	}
+}
+
+func TestObservableList_RemoveIndices(t *testing.T) {
+	list := ObserveList[int](NewList(1, 2, 3, 2, 4, 2))
+	var changes []Change[int]
+	list.Subscribe(func(event ChangeEvent[int]) {
+		changes = append(changes, event.Changes...)
+	})
+
+	list.RemoveAllSlice([]int{2, 4, 2})
+	list.RemoveIf(func(el int) bool {
+		return el != 3
+	})
+	indices := make([]int, 0, len(changes))
+	for _, c := range changes {
+		indices = append(indices, c.Index)
+	}
+	if !NewList(indices...).Equal(NewList(1, 2, 2, 0, 1)) || list.Size() != 1 || list.Get(0) != 3 {
+		t.Errorf("expected error, indices=%v, list=%s", indices, list)
+	}
+}
+
+func TestObservable_CancelInsideCallback(t *testing.T) {
+	set := Observable[int](NewSet[int]())
+	events, unsubscribeChan := set.SubscribeChan(1)
+	calls := 0
+	var unsubscribe func()
+	unsubscribe = set.Subscribe(func(ChangeEvent[int]) {
+		calls++
+		unsubscribe()
+		unsubscribeChan()
+	})
+
+	set.Add(1)
+	set.Add(2)
+	if calls != 1 || set.Size() != 2 {
+		t.Errorf("expected error, calls=%d, set=%s", calls, set)
+	}
+	if _, ok := <-events; !ok {
+		t.Errorf("expected error, first event missing")
+	}
+	if _, ok := <-events; ok {
+		t.Errorf("expected error, channel not closed")
+	}
+}
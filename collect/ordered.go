@@ -0,0 +1,10 @@
+package collect
+
+// Ordered matches the types supporting < and friends, like cmp.Ordered in
+// newer Go releases; the module still targets Go 1.19.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
@@ -1,6 +1,6 @@
 package collect
 
-type Queue[T comparable] interface {
+type Queue[T any] interface {
 	Offer(element T)
 	Pool() T
 	Peek() T
@@ -11,9 +11,7 @@ type PrimaryQueue[T comparable] struct {
 }
 
 func NewQueue[T comparable](elements ...T) *PrimaryQueue[T] {
-	return &PrimaryQueue[T]{
-		collectionWithSlice: collectionWithSlice[T]{data: &elements},
-	}
+	return &PrimaryQueue[T]{newCollectionWithSlice(&elements)}
 }
 
 func (p *PrimaryQueue[T]) Offer(element T) {
@@ -21,13 +19,17 @@ func (p *PrimaryQueue[T]) Offer(element T) {
 }
 
 func (p *PrimaryQueue[T]) Pool() T {
-	result := (*p.data)[0]
-	*p.data = (*p.data)[1:]
-	return result
+	return p.Any().Pool()
 }
 
 func (p *PrimaryQueue[T]) Peek() T {
-	return (*p.data)[0]
+	return p.Any().Peek()
+}
+
+// Any returns a view of p that compares elements with ==. Changes made through
+// the view are visible in p and the other way round.
+func (p *PrimaryQueue[T]) Any() *AnyQueue[T] {
+	return &AnyQueue[T]{p.sliceCollection}
 }
 
 func (p *PrimaryQueue[T]) Equal(elements *PrimaryQueue[T]) bool {
@@ -47,3 +49,32 @@ func (p *PrimaryQueue[T]) Equal(elements *PrimaryQueue[T]) bool {
 	}
 	return true
 }
+
+type AnyQueue[T any] struct {
+	sliceCollection[T]
+}
+
+// NewAnyQueue compares elements with reflect.DeepEqual; use NewAnyQueueFunc to
+// supply the equality.
+func NewAnyQueue[T any](elements ...T) *AnyQueue[T] {
+	return NewAnyQueueFunc(deepEqual[T], elements...)
+}
+
+func NewAnyQueueFunc[T any](equal func(a, b T) bool, elements ...T) *AnyQueue[T] {
+	return &AnyQueue[T]{newSliceCollection(&elements, equal)}
+}
+
+func (p *AnyQueue[T]) Offer(element T) {
+	p.Add(element)
+}
+
+func (p *AnyQueue[T]) Pool() T {
+	result := (*p.data)[0]
+	*p.data = (*p.data)[1:]
+	p.mods++
+	return result
+}
+
+func (p *AnyQueue[T]) Peek() T {
+	return (*p.data)[0]
+}
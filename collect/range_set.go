@@ -0,0 +1,142 @@
+package collect
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Range[K Ordered] struct {
+	Lo K
+	Hi K
+}
+
+func (r Range[K]) Contains(point K) bool {
+	return r.Lo <= point && point < r.Hi
+}
+
+func (r Range[K]) String() string {
+	return fmt.Sprintf("[%v, %v)", r.Lo, r.Hi)
+}
+
+// RangeSet holds a union of half-open ranges [Lo, Hi). Overlapping and
+// adjacent ranges are coalesced, so the stored ranges are disjoint and sorted.
+type RangeSet[K Ordered] struct {
+	ranges []Range[K]
+}
+
+func NewRangeSet[K Ordered](ranges ...Range[K]) *RangeSet[K] {
+	set := &RangeSet[K]{}
+	for _, r := range ranges {
+		set.Add(r.Lo, r.Hi)
+	}
+	return set
+}
+
+func (s *RangeSet[K]) Add(lo, hi K) {
+	if !(lo < hi) {
+		return
+	}
+	first := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].Hi >= lo
+	})
+	last := first
+	for last < len(s.ranges) && s.ranges[last].Lo <= hi {
+		if s.ranges[last].Lo < lo {
+			lo = s.ranges[last].Lo
+		}
+		if s.ranges[last].Hi > hi {
+			hi = s.ranges[last].Hi
+		}
+		last++
+	}
+
+	merged := append([]Range[K]{{Lo: lo, Hi: hi}}, s.ranges[last:]...)
+	s.ranges = append(s.ranges[:first], merged...)
+}
+
+func (s *RangeSet[K]) Remove(lo, hi K) {
+	if !(lo < hi) {
+		return
+	}
+	var result []Range[K]
+	for _, r := range s.ranges {
+		if r.Hi <= lo || hi <= r.Lo {
+			result = append(result, r)
+			continue
+		}
+		if r.Lo < lo {
+			result = append(result, Range[K]{Lo: r.Lo, Hi: lo})
+		}
+		if hi < r.Hi {
+			result = append(result, Range[K]{Lo: hi, Hi: r.Hi})
+		}
+	}
+	s.ranges = result
+}
+
+func (s *RangeSet[K]) Contains(point K) bool {
+	idx := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].Hi > point
+	})
+	return idx < len(s.ranges) && s.ranges[idx].Contains(point)
+}
+
+func (s *RangeSet[K]) Encloses(lo, hi K) bool {
+	idx := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].Hi > lo
+	})
+	return idx < len(s.ranges) && s.ranges[idx].Lo <= lo && hi <= s.ranges[idx].Hi
+}
+
+// Complement returns the parts of [lo, hi) not covered by the set.
+func (s *RangeSet[K]) Complement(lo, hi K) *RangeSet[K] {
+	result := NewRangeSet(Range[K]{Lo: lo, Hi: hi})
+	for _, r := range s.ranges {
+		result.Remove(r.Lo, r.Hi)
+	}
+	return result
+}
+
+func (s *RangeSet[K]) Span() (Range[K], bool) {
+	if len(s.ranges) == 0 {
+		return Range[K]{}, false
+	}
+	return Range[K]{Lo: s.ranges[0].Lo, Hi: s.ranges[len(s.ranges)-1].Hi}, true
+}
+
+func (s *RangeSet[K]) Ranges() *ArrayList[Range[K]] {
+	return NewList(append([]Range[K](nil), s.ranges...)...)
+}
+
+func (s *RangeSet[K]) Size() int {
+	return len(s.ranges)
+}
+
+func (s *RangeSet[K]) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+func (s *RangeSet[K]) Clear() {
+	s.ranges = nil
+}
+
+func (s *RangeSet[K]) Equal(other *RangeSet[K]) bool {
+	if other == nil || len(s.ranges) != len(other.ranges) {
+		return false
+	}
+	for i, r := range s.ranges {
+		if r != other.ranges[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *RangeSet[K]) String() string {
+	var data []string
+	for _, r := range s.ranges {
+		data = append(data, r.String())
+	}
+	return "[" + strings.Join(data, " ") + "]"
+}
@@ -0,0 +1,273 @@
+package collect
+
+import (
+	"fmt"
+	"strings"
+)
+
+type OverflowPolicy int
+
+const (
+	Overwrite OverflowPolicy = iota
+	Reject
+)
+
+// RingBuffer is a fixed-capacity FIFO queue. When it is full, Offer either
+// drops the oldest element (Overwrite) or the new one (Reject).
+type RingBuffer[T comparable] struct {
+	data   []T
+	head   int
+	size   int
+	mods   int
+	policy OverflowPolicy
+}
+
+func NewRingBuffer[T comparable](capacity int, policy OverflowPolicy) *RingBuffer[T] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("collect: non-positive capacity %d", capacity))
+	}
+	return &RingBuffer[T]{
+		data:   make([]T, capacity),
+		policy: policy,
+	}
+}
+
+func (r *RingBuffer[T]) Capacity() int {
+	return len(r.data)
+}
+
+func (r *RingBuffer[T]) IsFull() bool {
+	return r.size == len(r.data)
+}
+
+func (r *RingBuffer[T]) Policy() OverflowPolicy {
+	return r.policy
+}
+
+func (r *RingBuffer[T]) TryOffer(element T) bool {
+	if r.IsFull() {
+		if r.policy == Reject {
+			return false
+		}
+		r.head = (r.head + 1) % len(r.data)
+		r.size--
+	}
+	r.data[(r.head+r.size)%len(r.data)] = element
+	r.size++
+	r.mods++
+	return true
+}
+
+func (r *RingBuffer[T]) Offer(element T) {
+	r.TryOffer(element)
+}
+
+func (r *RingBuffer[T]) Pool() T {
+	if r.size == 0 {
+		panic("collect: Pool on empty RingBuffer")
+	}
+	var zero T
+	result := r.data[r.head]
+	r.data[r.head] = zero
+	r.head = (r.head + 1) % len(r.data)
+	r.size--
+	r.mods++
+	return result
+}
+
+func (r *RingBuffer[T]) Peek() T {
+	if r.size == 0 {
+		panic("collect: Peek on empty RingBuffer")
+	}
+	return r.data[r.head]
+}
+
+// Get returns the element at index, counting from the oldest element.
+func (r *RingBuffer[T]) Get(index int) T {
+	if index < 0 || index >= r.size {
+		panic(fmt.Sprintf("collect: index %d out of range [0, %d)", index, r.size))
+	}
+	return r.data[(r.head+index)%len(r.data)]
+}
+
+func (r *RingBuffer[T]) SafeGet(index int) (T, bool) {
+	if index < 0 || index >= r.size {
+		var t T
+		return t, false
+	}
+	return r.data[(r.head+index)%len(r.data)], true
+}
+
+func (r *RingBuffer[T]) IndexOf(element T) int {
+	for i := 0; i < r.size; i++ {
+		if r.data[(r.head+i)%len(r.data)] == element {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *RingBuffer[T]) Slice() []T {
+	result := make([]T, r.size)
+	for i := range result {
+		result[i] = r.data[(r.head+i)%len(r.data)]
+	}
+	return result
+}
+
+func (r *RingBuffer[T]) Add(element T) {
+	r.TryOffer(element)
+}
+
+func (r *RingBuffer[T]) AddAll(elements Collection[T]) {
+	for el := range elements.Iterator() {
+		r.Add(el)
+	}
+}
+
+func (r *RingBuffer[T]) AddAllSlice(elements []T) {
+	for _, el := range elements {
+		r.Add(el)
+	}
+}
+
+func (r *RingBuffer[T]) Contains(element T) bool {
+	return r.IndexOf(element) != -1
+}
+
+func (r *RingBuffer[T]) ContainsAll(elements Collection[T]) bool {
+	for el := range elements.Iterator() {
+		if !r.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *RingBuffer[T]) ContainsAllSlice(elements []T) bool {
+	for _, el := range elements {
+		if !r.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *RingBuffer[T]) Remove(element T) bool {
+	removed := false
+	return r.RemoveIf(func(el T) bool {
+		if !removed && el == element {
+			removed = true
+			return true
+		}
+		return false
+	})
+}
+
+func (r *RingBuffer[T]) RemoveAll(elements Collection[T]) bool {
+	modified := false
+	for el := range elements.Iterator() {
+		if r.Remove(el) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (r *RingBuffer[T]) RemoveAllSlice(elements []T) bool {
+	modified := false
+	for _, el := range elements {
+		if r.Remove(el) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (r *RingBuffer[T]) RemoveIf(predicate func(T) bool) bool {
+	kept := 0
+	for i := 0; i < r.size; i++ {
+		el := r.data[(r.head+i)%len(r.data)]
+		if !predicate(el) {
+			r.data[(r.head+kept)%len(r.data)] = el
+			kept++
+		}
+	}
+
+	var zero T
+	for i := kept; i < r.size; i++ {
+		r.data[(r.head+i)%len(r.data)] = zero
+	}
+	modified := kept != r.size
+	r.size = kept
+	if modified {
+		r.mods++
+	}
+	return modified
+}
+
+func (r *RingBuffer[T]) Size() int {
+	return r.size
+}
+
+func (r *RingBuffer[T]) IsEmpty() bool {
+	return r.size == 0
+}
+
+func (r *RingBuffer[T]) Clear() {
+	var zero T
+	for i := range r.data {
+		r.data[i] = zero
+	}
+	r.head = 0
+	r.size = 0
+	r.mods++
+}
+
+func (r *RingBuffer[T]) Iterator() <-chan T {
+	pool := make(chan T, r.size)
+	defer close(pool)
+
+	for i := 0; i < r.size; i++ {
+		pool <- r.data[(r.head+i)%len(r.data)]
+	}
+
+	return pool
+}
+
+func (r *RingBuffer[T]) ForEach(do func(T)) {
+	mods := r.mods
+	for i := 0; i < r.size; i++ {
+		do(r.data[(r.head+i)%len(r.data)])
+		checkModifications(mods, r.mods)
+	}
+}
+
+// Iter visits the elements from oldest to newest.
+func (r *RingBuffer[T]) Iter() Iterator[T] {
+	return NewIterator(r.Slice(), func() int {
+		return r.mods
+	}, func(index int, _ T) {
+		r.removeAt(index)
+	})
+}
+
+// removeAt closes the gap left by the element at index by shifting the newer
+// elements one slot towards the head.
+func (r *RingBuffer[T]) removeAt(index int) {
+	for i := index; i < r.size-1; i++ {
+		r.data[(r.head+i)%len(r.data)] = r.data[(r.head+i+1)%len(r.data)]
+	}
+	var zero T
+	r.data[(r.head+r.size-1)%len(r.data)] = zero
+	r.size--
+	r.mods++
+}
+
+func (r *RingBuffer[T]) String() string {
+	var data []string
+	r.ForEach(func(el T) {
+		data = append(data, fmt.Sprint(el))
+	})
+	return "[" + strings.Join(data, " ") + "]"
+}
@@ -0,0 +1,47 @@
+package collect
+
+import "testing"
+
+func TestRingBuffer_Overwrite(t *testing.T) {
+	ring := NewRingBuffer[int](3, Overwrite)
+	ring.AddAllSlice([]int{1, 2, 3, 4, 5})
+	if !ring.IsFull() || ring.Get(0) != 3 || ring.Get(2) != 5 {
+		t.Errorf("expected error, oldest not dropped %s", ring)
+	}
+	if ring.Pool() != 3 || ring.Peek() != 4 || ring.Size() != 2 {
+		t.Errorf("expected error, incorrect Pool %s", ring)
+	}
+	ring.Offer(6)
+	ring.Offer(7)
+	if !ring.ContainsAllSlice([]int{5, 6, 7}) || ring.Contains(4) {
+		t.Errorf("expected error, incorrect contents %s", ring)
+	}
+}
+
+func TestRingBuffer_Reject(t *testing.T) {
+	ring := NewRingBuffer[int](2, Reject)
+	if !ring.TryOffer(1) || !ring.TryOffer(2) || ring.TryOffer(3) {
+		t.Errorf("expected error, full buffer accepted element %s", ring)
+	}
+	if _, ok := ring.SafeGet(2); ok {
+		t.Errorf("expected error, got element at %d", 2)
+	}
+}
+
+func TestRingBuffer_Remove(t *testing.T) {
+	ring := NewRingBuffer[int](5, Overwrite)
+	ring.AddAllSlice([]int{1, 2, 3, 4, 5, 6, 7})
+	if !ring.Remove(5) || ring.Remove(1) {
+		t.Errorf("expected error, incorrect Remove %s", ring)
+	}
+	ring.RemoveIf(func(i int) bool {
+		return i%2 == 1
+	})
+	if ring.String() != "[4 6]" {
+		t.Errorf("expected error, elements=%s, got=%s", "[4 6]", ring)
+	}
+	ring.AddAllSlice([]int{8, 9, 10})
+	if ring.Get(0) != 4 || ring.Get(4) != 10 || ring.IndexOf(9) != 3 {
+		t.Errorf("expected error, incorrect order after wrap %s", ring)
+	}
+}
@@ -0,0 +1,241 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+)
+
+// The helpers below return a new ArrayList. Every one of them also has a Lazy
+// variant that produces its elements on demand through an unbuffered channel,
+// which is closed once all elements are sent or ctx is done. Lazy variants
+// read their input lists as they go, so the lists must not be modified until
+// the channel is closed. A caller that stops receiving early must cancel ctx;
+// otherwise the goroutine feeding the channel never exits.
+
+type Pair[A comparable, B comparable] struct {
+	First  A
+	Second B
+}
+
+func (p Pair[A, B]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
+// Chunk splits list into consecutive sublists of n elements; the last one may
+// be shorter.
+func Chunk[T comparable](list *ArrayList[T], n int) *ArrayList[*ArrayList[T]] {
+	return collectList(chunks(list, n))
+}
+
+func ChunkLazy[T comparable](ctx context.Context, list *ArrayList[T], n int) <-chan *ArrayList[T] {
+	return generate(ctx, chunks(list, n))
+}
+
+// SlidingWindow returns every sublist of size elements starting at 0, step,
+// 2*step and so on that fits in list.
+func SlidingWindow[T comparable](list *ArrayList[T], size, step int) *ArrayList[*ArrayList[T]] {
+	return collectList(windows(list, size, step))
+}
+
+func SlidingWindowLazy[T comparable](ctx context.Context, list *ArrayList[T], size, step int) <-chan *ArrayList[T] {
+	return generate(ctx, windows(list, size, step))
+}
+
+// Zip pairs up the elements of a and b at the same index, stopping at the end
+// of the shorter list.
+func Zip[A comparable, B comparable](a *ArrayList[A], b *ArrayList[B]) *ArrayList[Pair[A, B]] {
+	return ZipWith(a, b, newPair[A, B])
+}
+
+func ZipLazy[A comparable, B comparable](ctx context.Context, a *ArrayList[A], b *ArrayList[B]) <-chan Pair[A, B] {
+	return ZipWithLazy(ctx, a, b, newPair[A, B])
+}
+
+func ZipWith[A comparable, B comparable, R comparable](a *ArrayList[A], b *ArrayList[B], fn func(A, B) R) *ArrayList[R] {
+	return collectList(zipped(a, b, fn))
+}
+
+func ZipWithLazy[A comparable, B comparable, R comparable](ctx context.Context, a *ArrayList[A], b *ArrayList[B], fn func(A, B) R) <-chan R {
+	return generate(ctx, zipped(a, b, fn))
+}
+
+func Unzip[A comparable, B comparable](pairs *ArrayList[Pair[A, B]]) (*ArrayList[A], *ArrayList[B]) {
+	return collectList(mapped(pairs, Pair[A, B].first)), collectList(mapped(pairs, Pair[A, B].second))
+}
+
+// UnzipLazy returns two independent channels, each of which can be drained
+// without the other.
+func UnzipLazy[A comparable, B comparable](ctx context.Context, pairs *ArrayList[Pair[A, B]]) (<-chan A, <-chan B) {
+	return generate(ctx, mapped(pairs, Pair[A, B].first)), generate(ctx, mapped(pairs, Pair[A, B].second))
+}
+
+func Flatten[T comparable](lists *ArrayList[*ArrayList[T]]) *ArrayList[T] {
+	return collectList(flattened(lists))
+}
+
+func FlattenLazy[T comparable](ctx context.Context, lists *ArrayList[*ArrayList[T]]) <-chan T {
+	return generate(ctx, flattened(lists))
+}
+
+// Interleave takes one element of each list in turn, skipping the lists that
+// are exhausted.
+func Interleave[T comparable](lists ...*ArrayList[T]) *ArrayList[T] {
+	return collectList(interleaved(lists))
+}
+
+func InterleaveLazy[T comparable](ctx context.Context, lists ...*ArrayList[T]) <-chan T {
+	return generate(ctx, interleaved(lists))
+}
+
+// Partition splits list into k consecutive sublists whose sizes differ by at
+// most one, the longer ones first.
+func Partition[T comparable](list *ArrayList[T], k int) *ArrayList[*ArrayList[T]] {
+	return collectList(parts(list, k))
+}
+
+func PartitionLazy[T comparable](ctx context.Context, list *ArrayList[T], k int) <-chan *ArrayList[T] {
+	return generate(ctx, parts(list, k))
+}
+
+// producer sends its elements to yield until yield returns false.
+type producer[T any] func(yield func(T) bool)
+
+func collectList[T comparable](produce producer[T]) *ArrayList[T] {
+	result := NewList[T]()
+	produce(func(el T) bool {
+		result.Add(el)
+		return true
+	})
+	return result
+}
+
+func generate[T any](ctx context.Context, produce producer[T]) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		produce(func(el T) bool {
+			select {
+			case out <- el:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return out
+}
+
+func newPair[A comparable, B comparable](a A, b B) Pair[A, B] {
+	return Pair[A, B]{First: a, Second: b}
+}
+
+func (p Pair[A, B]) first() A {
+	return p.First
+}
+
+func (p Pair[A, B]) second() B {
+	return p.Second
+}
+
+func sublist[T comparable](data []T) *ArrayList[T] {
+	return NewList(append([]T(nil), data...)...)
+}
+
+func chunks[T comparable](list *ArrayList[T], n int) producer[*ArrayList[T]] {
+	if n <= 0 {
+		panic(fmt.Sprintf("collect: non-positive chunk size %d", n))
+	}
+	return func(yield func(*ArrayList[T]) bool) {
+		for from := 0; from < list.Size(); from += n {
+			to := from + n
+			if to > list.Size() {
+				to = list.Size()
+			}
+			if !yield(sublist((*list.data)[from:to])) {
+				return
+			}
+		}
+	}
+}
+
+func windows[T comparable](list *ArrayList[T], size, step int) producer[*ArrayList[T]] {
+	if size <= 0 || step <= 0 {
+		panic(fmt.Sprintf("collect: non-positive window size %d or step %d", size, step))
+	}
+	return func(yield func(*ArrayList[T]) bool) {
+		for from := 0; from+size <= list.Size(); from += step {
+			if !yield(sublist((*list.data)[from : from+size])) {
+				return
+			}
+		}
+	}
+}
+
+func parts[T comparable](list *ArrayList[T], k int) producer[*ArrayList[T]] {
+	if k <= 0 {
+		panic(fmt.Sprintf("collect: non-positive partition count %d", k))
+	}
+	return func(yield func(*ArrayList[T]) bool) {
+		size, extra := list.Size()/k, list.Size()%k
+		from := 0
+		for i := 0; i < k; i++ {
+			to := from + size
+			if i < extra {
+				to++
+			}
+			if !yield(sublist((*list.data)[from:to])) {
+				return
+			}
+			from = to
+		}
+	}
+}
+
+func zipped[A comparable, B comparable, R comparable](a *ArrayList[A], b *ArrayList[B], fn func(A, B) R) producer[R] {
+	return func(yield func(R) bool) {
+		for i := 0; i < a.Size() && i < b.Size(); i++ {
+			if !yield(fn(a.Get(i), b.Get(i))) {
+				return
+			}
+		}
+	}
+}
+
+func mapped[T comparable, R any](list *ArrayList[T], fn func(T) R) producer[R] {
+	return func(yield func(R) bool) {
+		for i := 0; i < list.Size(); i++ {
+			if !yield(fn(list.Get(i))) {
+				return
+			}
+		}
+	}
+}
+
+func flattened[T comparable](lists *ArrayList[*ArrayList[T]]) producer[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < lists.Size(); i++ {
+			list := lists.Get(i)
+			for j := 0; j < list.Size(); j++ {
+				if !yield(list.Get(j)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func interleaved[T comparable](lists []*ArrayList[T]) producer[T] {
+	return func(yield func(T) bool) {
+		for i, remaining := 0, true; remaining; i++ {
+			remaining = false
+			for _, list := range lists {
+				if i < list.Size() {
+					remaining = true
+					if !yield(list.Get(i)) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package collect
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestChunk(t *testing.T) {
+	chunks := Chunk(NewList(1, 2, 3, 4, 5), 2)
+	if chunks.Size() != 3 || !chunks.Get(2).Equal(NewList(5)) {
+		t.Errorf("expected error, chunks=%s", chunks)
+	}
+
+	chunks.Get(0).Add(9)
+	if !chunks.Get(1).Equal(NewList(3, 4)) {
+		t.Errorf("expected error, chunks share storage %s", chunks)
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	windows := SlidingWindow(NewList(1, 2, 3, 4, 5), 3, 2)
+	if windows.Size() != 2 || !windows.Get(1).Equal(NewList(3, 4, 5)) {
+		t.Errorf("expected error, windows=%s", windows)
+	}
+}
+
+func TestZipUnzip(t *testing.T) {
+	pairs := Zip(NewList(1, 2, 3), NewList("a", "b"))
+	if pairs.Size() != 2 || pairs.Get(1) != (Pair[int, string]{2, "b"}) {
+		t.Errorf("expected error, pairs=%s", pairs)
+	}
+
+	numbers, letters := Unzip(pairs)
+	if !numbers.Equal(NewList(1, 2)) || !letters.Equal(NewList("a", "b")) {
+		t.Errorf("expected error, unzipped=%s %s", numbers, letters)
+	}
+
+	sums := ZipWith(NewList(1, 2), NewList(10, 20), func(a, b int) int {
+		return a + b
+	})
+	if !sums.Equal(NewList(11, 22)) {
+		t.Errorf("expected error, sums=%s", sums)
+	}
+}
+
+func TestFlattenInterleave(t *testing.T) {
+	a, b := NewList(1, 2, 3), NewList(4)
+	if flat := Flatten(NewList(a, b)); !flat.Equal(NewList(1, 2, 3, 4)) {
+		t.Errorf("expected error, flat=%s", flat)
+	}
+	if mixed := Interleave(a, b, NewList(5, 6)); !mixed.Equal(NewList(1, 4, 5, 2, 6, 3)) {
+		t.Errorf("expected error, mixed=%s", mixed)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	parts := Partition(NewList(1, 2, 3, 4, 5, 6, 7), 3)
+	if parts.Size() != 3 || parts.Get(0).Size() != 3 || parts.Get(2).Size() != 2 {
+		t.Errorf("expected error, parts=%s", parts)
+	}
+	if empty := Partition(NewList(1), 3); empty.Size() != 3 || !empty.Get(2).IsEmpty() {
+		t.Errorf("expected error, parts=%s", empty)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	chunks := ChunkLazy(context.Background(), list, 2)
+	if collected := FromChan(context.Background(), chunks); collected.Size() != 3 {
+		t.Errorf("expected error, chunks=%s", collected)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	windows := SlidingWindowLazy(ctx, list, 2, 1)
+	if first := <-windows; !first.Equal(NewList(1, 2)) {
+		t.Errorf("expected error, window=%s", first)
+	}
+	cancel()
+	for range windows {
+	}
+
+	numbers, letters := UnzipLazy(context.Background(), Zip(NewList(1, 2), NewList("a", "b")))
+	if !FromChan(context.Background(), letters).Equal(NewList("a", "b")) ||
+		!FromChan(context.Background(), numbers).Equal(NewList(1, 2)) {
+		t.Errorf("expected error, incorrect lazy unzip")
+	}
+}
+
+func TestChunk_ConcurrentReaders(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	done := make(chan *ArrayList[*ArrayList[int]])
+	for i := 0; i < 2; i++ {
+		go func() {
+			done <- Chunk(list, 2)
+		}()
+	}
+	if (<-done).Size() != 3 || (<-done).Size() != 3 {
+		t.Errorf("expected error, incorrect chunks")
+	}
+}
+
+func TestLazy_CancelStopsGoroutine(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	lists := NewList(NewList(1, 2), NewList(3, 4))
+	flat := FlattenLazy(ctx, lists)
+	if el := <-flat; el != 1 {
+		t.Errorf("expected error, element=%d, got=%d", 1, el)
+	}
+
+	cancel()
+	for range flat {
+	}
+	for deadline := time.Now().Add(time.Second); runtime.NumGoroutine() > before; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected error, goroutines=%d, got=%d", before, runtime.NumGoroutine())
+		}
+		runtime.Gosched()
+	}
+}
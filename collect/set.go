@@ -5,17 +5,19 @@ import (
 	"strings"
 )
 
-type Set[T comparable] interface {
+type Set[T any] interface {
 	Equal(elements Set[T]) bool
 	Collection[T]
 }
 
 type HashSet[T comparable] struct {
-	data map[any]interface{}
+	data   map[any]interface{}
+	mods   int
+	shared bool
 }
 
 func NewSet[T comparable](elements ...T) *HashSet[T] {
-	set := &HashSet[T]{make(map[any]interface{})}
+	set := &HashSet[T]{data: make(map[any]interface{})}
 	for _, el := range elements {
 		set.data[el] = nil
 	}
@@ -23,7 +25,7 @@ func NewSet[T comparable](elements ...T) *HashSet[T] {
 }
 
 func NewSetOf[T comparable](elements Collection[T]) *HashSet[T] {
-	set := &HashSet[T]{make(map[any]interface{})}
+	set := &HashSet[T]{data: make(map[any]interface{})}
 	for el := range elements.Iterator() {
 		set.data[el] = nil
 	}
@@ -78,9 +80,13 @@ func (s *HashSet[T]) ContainsAllSlice(elements []T) bool {
 }
 
 func (s *HashSet[T]) Remove(element T) bool {
-	delete(s.data, element)
 	_, ok := s.data[element]
-	return !ok
+	if ok {
+		s.own()
+		delete(s.data, element)
+		s.mods++
+	}
+	return ok
 }
 
 func (s *HashSet[T]) RemoveAll(elements Collection[T]) bool {
@@ -116,25 +122,34 @@ func (s *HashSet[T]) RemoveIf(predicate func(T) bool) bool {
 }
 
 func (s *HashSet[T]) Add(element T) {
-	s.data[element] = nil
+	if _, ok := s.data[element]; !ok {
+		s.own()
+		s.data[element] = nil
+		s.mods++
+	}
 }
 
 func (s *HashSet[T]) AddAll(elements Collection[T]) {
 	for el := range elements.Iterator() {
-		s.data[el] = nil
+		s.Add(el)
 	}
 }
 
 func (s *HashSet[T]) AddAllSlice(elements []T) {
 	for _, el := range elements {
-		s.data[el] = nil
+		s.Add(el)
 	}
 }
 
 func (s *HashSet[T]) Clear() {
+	if s.shared {
+		s.data = make(map[any]interface{})
+		s.shared = false
+	}
 	for k := range s.data {
 		delete(s.data, k)
 	}
+	s.mods++
 }
 
 func (s *HashSet[T]) Iterator() <-chan T {
@@ -149,9 +164,23 @@ func (s *HashSet[T]) Iterator() <-chan T {
 }
 
 func (s *HashSet[T]) ForEach(do func(T)) {
+	mods := s.mods
 	for key := range s.data {
 		do(key.(T))
+		checkModifications(mods, s.mods)
+	}
+}
+
+func (s *HashSet[T]) Iter() Iterator[T] {
+	keys := make([]T, 0, len(s.data))
+	for key := range s.data {
+		keys = append(keys, key.(T))
 	}
+	return NewIterator(keys, func() int {
+		return s.mods
+	}, func(_ int, el T) {
+		s.Remove(el)
+	})
 }
 
 func (s *HashSet[T]) String() string {
@@ -161,3 +190,219 @@ func (s *HashSet[T]) String() string {
 	}
 	return "[" + strings.Join(data, " ") + "]"
 }
+
+type EquivalenceSet[T any] struct {
+	eq      Equivalence[T]
+	buckets map[uint64][]T
+	size    int
+	mods    int
+}
+
+func NewSetWith[T any](eq Equivalence[T], elements ...T) *EquivalenceSet[T] {
+	set := &EquivalenceSet[T]{eq: eq, buckets: make(map[uint64][]T)}
+	set.AddAllSlice(elements)
+	return set
+}
+
+func NewSetWithOf[T any](eq Equivalence[T], elements Collection[T]) *EquivalenceSet[T] {
+	set := &EquivalenceSet[T]{eq: eq, buckets: make(map[uint64][]T)}
+	set.AddAll(elements)
+	return set
+}
+
+func (s *EquivalenceSet[T]) Equivalence() Equivalence[T] {
+	return s.eq
+}
+
+func (s *EquivalenceSet[T]) Equal(elements Set[T]) bool {
+	if elements == nil {
+		return false
+	}
+	if s.size != elements.Size() {
+		return false
+	}
+
+	for _, bucket := range s.buckets {
+		for _, el := range bucket {
+			if !elements.Contains(el) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *EquivalenceSet[T]) Size() int {
+	return s.size
+}
+
+func (s *EquivalenceSet[T]) IsEmpty() bool {
+	return s.size == 0
+}
+
+func (s *EquivalenceSet[T]) Contains(element T) bool {
+	_, idx := s.find(element)
+	return idx != -1
+}
+
+func (s *EquivalenceSet[T]) ContainsAll(elements Collection[T]) bool {
+	for el := range elements.Iterator() {
+		if !s.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *EquivalenceSet[T]) ContainsAllSlice(elements []T) bool {
+	for _, e := range elements {
+		if !s.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *EquivalenceSet[T]) Remove(element T) bool {
+	hash, idx := s.find(element)
+	if idx == -1 {
+		return false
+	}
+
+	bucket := s.buckets[hash]
+	if len(bucket) == 1 {
+		delete(s.buckets, hash)
+	} else {
+		s.buckets[hash] = append(bucket[:idx], bucket[idx+1:]...)
+	}
+	s.size--
+	s.mods++
+	return true
+}
+
+func (s *EquivalenceSet[T]) RemoveAll(elements Collection[T]) bool {
+	modified := false
+	for el := range elements.Iterator() {
+		if s.Remove(el) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (s *EquivalenceSet[T]) RemoveAllSlice(elements []T) bool {
+	modified := false
+	for _, e := range elements {
+		if s.Remove(e) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (s *EquivalenceSet[T]) RemoveIf(predicate func(T) bool) bool {
+	modified := false
+	for hash, bucket := range s.buckets {
+		kept := bucket[:0]
+		for _, el := range bucket {
+			if predicate(el) {
+				s.size--
+				modified = true
+			} else {
+				kept = append(kept, el)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.buckets, hash)
+		} else {
+			s.buckets[hash] = kept
+		}
+	}
+	if modified {
+		s.mods++
+	}
+	return modified
+}
+
+func (s *EquivalenceSet[T]) Add(element T) {
+	hash, idx := s.find(element)
+	if idx != -1 {
+		return
+	}
+	s.buckets[hash] = append(s.buckets[hash], element)
+	s.size++
+	s.mods++
+}
+
+func (s *EquivalenceSet[T]) AddAll(elements Collection[T]) {
+	for el := range elements.Iterator() {
+		s.Add(el)
+	}
+}
+
+func (s *EquivalenceSet[T]) AddAllSlice(elements []T) {
+	for _, el := range elements {
+		s.Add(el)
+	}
+}
+
+func (s *EquivalenceSet[T]) Clear() {
+	s.buckets = make(map[uint64][]T)
+	s.size = 0
+	s.mods++
+}
+
+func (s *EquivalenceSet[T]) Iterator() <-chan T {
+	pool := make(chan T, s.size)
+	defer close(pool)
+
+	for _, bucket := range s.buckets {
+		for _, el := range bucket {
+			pool <- el
+		}
+	}
+
+	return pool
+}
+
+func (s *EquivalenceSet[T]) ForEach(do func(T)) {
+	mods := s.mods
+	for _, bucket := range s.buckets {
+		for _, el := range bucket {
+			do(el)
+			checkModifications(mods, s.mods)
+		}
+	}
+}
+
+func (s *EquivalenceSet[T]) Iter() Iterator[T] {
+	elements := make([]T, 0, s.size)
+	for _, bucket := range s.buckets {
+		elements = append(elements, bucket...)
+	}
+	return NewIterator(elements, func() int {
+		return s.mods
+	}, func(_ int, el T) {
+		s.Remove(el)
+	})
+}
+
+func (s *EquivalenceSet[T]) String() string {
+	var data []string
+	for _, bucket := range s.buckets {
+		for _, el := range bucket {
+			data = append(data, fmt.Sprint(el))
+		}
+	}
+	return "[" + strings.Join(data, " ") + "]"
+}
+
+func (s *EquivalenceSet[T]) find(element T) (uint64, int) {
+	hash := s.eq.Hash(element)
+	for idx, el := range s.buckets[hash] {
+		if s.eq.Equal(el, element) {
+			return hash, idx
+		}
+	}
+	return hash, -1
+}
@@ -0,0 +1,282 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+const (
+	bloomMagic         = "BLM1"
+	countingBloomMagic = "CBF1"
+)
+
+type BloomFilter[T any] struct {
+	bits   []uint64
+	m      uint64
+	k      uint32
+	hasher Hasher[T]
+}
+
+func NewBloomFilter[T any](expected uint64, falsePositive float64, hasher Hasher[T]) *BloomFilter[T] {
+	m, k := optimalShape(expected, falsePositive)
+	return NewBloomFilterWithShape(m, k, hasher)
+}
+
+func NewBloomFilterWithShape[T any](m uint64, k uint32, hasher Hasher[T]) *BloomFilter[T] {
+	if m == 0 || k == 0 {
+		panic(fmt.Sprintf("sketch: invalid bloom filter shape m=%d k=%d", m, k))
+	}
+	return &BloomFilter[T]{
+		bits:   make([]uint64, (m+63)/64),
+		m:      m,
+		k:      k,
+		hasher: hasher,
+	}
+}
+
+func (f *BloomFilter[T]) Add(element T) {
+	h1, h2 := splitHash(f.hasher(element))
+	for i := uint32(0); i < f.k; i++ {
+		idx := (h1 + uint64(i)*h2) % f.m
+		f.bits[idx/64] |= 1 << (idx % 64)
+	}
+}
+
+func (f *BloomFilter[T]) AddAll(elements collect.Collection[T]) {
+	for el := range elements.Iterator() {
+		f.Add(el)
+	}
+}
+
+func (f *BloomFilter[T]) MightContain(element T) bool {
+	h1, h2 := splitHash(f.hasher(element))
+	for i := uint32(0); i < f.k; i++ {
+		idx := (h1 + uint64(i)*h2) % f.m
+		if f.bits[idx/64]&(1<<(idx%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *BloomFilter[T]) Union(other *BloomFilter[T]) (*BloomFilter[T], error) {
+	return f.combine(other, func(a, b uint64) uint64 { return a | b })
+}
+
+func (f *BloomFilter[T]) Intersect(other *BloomFilter[T]) (*BloomFilter[T], error) {
+	return f.combine(other, func(a, b uint64) uint64 { return a & b })
+}
+
+func (f *BloomFilter[T]) EstimatedCardinality() uint64 {
+	set := 0
+	for _, word := range f.bits {
+		set += bits.OnesCount64(word)
+	}
+	if uint64(set) == f.m {
+		return math.MaxUint64
+	}
+	m := float64(f.m)
+	return uint64(math.Round(-m / float64(f.k) * math.Log(1-float64(set)/m)))
+}
+
+func (f *BloomFilter[T]) FalsePositiveRate() float64 {
+	set := 0
+	for _, word := range f.bits {
+		set += bits.OnesCount64(word)
+	}
+	return math.Pow(float64(set)/float64(f.m), float64(f.k))
+}
+
+func (f *BloomFilter[T]) BitSize() uint64 {
+	return f.m
+}
+
+func (f *BloomFilter[T]) HashCount() uint32 {
+	return f.k
+}
+
+func (f *BloomFilter[T]) Clear() {
+	for i := range f.bits {
+		f.bits[i] = 0
+	}
+}
+
+func (f *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(bloomMagic)+12+8*len(f.bits))
+	data = append(data, bloomMagic...)
+	data = binary.LittleEndian.AppendUint64(data, f.m)
+	data = binary.LittleEndian.AppendUint32(data, f.k)
+	for _, word := range f.bits {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the shape and contents of f; the hasher of f is kept.
+func (f *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	m, k, payload, err := readShape(data, bloomMagic)
+	if err != nil {
+		return err
+	}
+	// Checking m against the payload first keeps the word count from
+	// overflowing for crafted input.
+	if m > 8*uint64(len(payload)) {
+		return ErrInvalidData
+	}
+	words := (m + 63) / 64
+	if uint64(len(payload)) != 8*words {
+		return ErrInvalidData
+	}
+
+	f.m, f.k = m, k
+	f.bits = make([]uint64, words)
+	for i := range f.bits {
+		f.bits[i] = binary.LittleEndian.Uint64(payload[8*i:])
+	}
+	return nil
+}
+
+func (f *BloomFilter[T]) combine(other *BloomFilter[T], op func(a, b uint64) uint64) (*BloomFilter[T], error) {
+	if f.m != other.m || f.k != other.k {
+		return nil, ErrShapeMismatch
+	}
+	result := NewBloomFilterWithShape(f.m, f.k, f.hasher)
+	for i := range f.bits {
+		result.bits[i] = op(f.bits[i], other.bits[i])
+	}
+	return result, nil
+}
+
+type CountingBloomFilter[T any] struct {
+	counters []uint8
+	m        uint64
+	k        uint32
+	hasher   Hasher[T]
+}
+
+func NewCountingBloomFilter[T any](expected uint64, falsePositive float64, hasher Hasher[T]) *CountingBloomFilter[T] {
+	m, k := optimalShape(expected, falsePositive)
+	return &CountingBloomFilter[T]{
+		counters: make([]uint8, m),
+		m:        m,
+		k:        k,
+		hasher:   hasher,
+	}
+}
+
+func (f *CountingBloomFilter[T]) Add(element T) {
+	h1, h2 := splitHash(f.hasher(element))
+	for i := uint32(0); i < f.k; i++ {
+		idx := (h1 + uint64(i)*h2) % f.m
+		if f.counters[idx] < math.MaxUint8 {
+			f.counters[idx]++
+		}
+	}
+}
+
+func (f *CountingBloomFilter[T]) AddAll(elements collect.Collection[T]) {
+	for el := range elements.Iterator() {
+		f.Add(el)
+	}
+}
+
+func (f *CountingBloomFilter[T]) MightContain(element T) bool {
+	h1, h2 := splitHash(f.hasher(element))
+	for i := uint32(0); i < f.k; i++ {
+		if f.counters[(h1+uint64(i)*h2)%f.m] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Remove decrements the counters of element and reports whether it might have
+// been present. Saturated counters are never decremented, since their true
+// value is unknown.
+func (f *CountingBloomFilter[T]) Remove(element T) bool {
+	if !f.MightContain(element) {
+		return false
+	}
+	h1, h2 := splitHash(f.hasher(element))
+	for i := uint32(0); i < f.k; i++ {
+		idx := (h1 + uint64(i)*h2) % f.m
+		if f.counters[idx] < math.MaxUint8 {
+			f.counters[idx]--
+		}
+	}
+	return true
+}
+
+func (f *CountingBloomFilter[T]) BitSize() uint64 {
+	return f.m
+}
+
+func (f *CountingBloomFilter[T]) HashCount() uint32 {
+	return f.k
+}
+
+func (f *CountingBloomFilter[T]) Clear() {
+	for i := range f.counters {
+		f.counters[i] = 0
+	}
+}
+
+func (f *CountingBloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(countingBloomMagic)+12+len(f.counters))
+	data = append(data, countingBloomMagic...)
+	data = binary.LittleEndian.AppendUint64(data, f.m)
+	data = binary.LittleEndian.AppendUint32(data, f.k)
+	return append(data, f.counters...), nil
+}
+
+// UnmarshalBinary replaces the shape and contents of f; the hasher of f is kept.
+func (f *CountingBloomFilter[T]) UnmarshalBinary(data []byte) error {
+	m, k, payload, err := readShape(data, countingBloomMagic)
+	if err != nil {
+		return err
+	}
+	if uint64(len(payload)) != m {
+		return ErrInvalidData
+	}
+
+	f.m, f.k = m, k
+	f.counters = append([]uint8(nil), payload...)
+	return nil
+}
+
+func optimalShape(expected uint64, falsePositive float64) (uint64, uint32) {
+	if expected == 0 {
+		expected = 1
+	}
+	if falsePositive <= 0 || falsePositive >= 1 {
+		panic(fmt.Sprintf("sketch: false positive rate %v out of range (0, 1)", falsePositive))
+	}
+	n := float64(expected)
+	m := math.Ceil(-n * math.Log(falsePositive) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / n * math.Ln2)
+	if k < 1 {
+		k = 1
+	}
+	return uint64(m), uint32(k)
+}
+
+func splitHash(h uint64) (uint64, uint64) {
+	return h, mix(h) | 1
+}
+
+func readShape(data []byte, magic string) (uint64, uint32, []byte, error) {
+	if len(data) < len(magic)+12 || string(data[:len(magic)]) != magic {
+		return 0, 0, nil, ErrInvalidData
+	}
+	data = data[len(magic):]
+	m := binary.LittleEndian.Uint64(data)
+	k := binary.LittleEndian.Uint32(data[8:])
+	if m == 0 || k == 0 {
+		return 0, 0, nil, ErrInvalidData
+	}
+	return m, k, data[12:], nil
+}
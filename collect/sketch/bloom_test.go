@@ -0,0 +1,126 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func TestBloomFilter_FalsePositiveRate(t *testing.T) {
+	filter := NewBloomFilter(10_000, 0.01, StableStringHasher())
+	for i := 0; i < 10_000; i++ {
+		filter.Add(strconv.Itoa(i))
+	}
+	for i := 0; i < 10_000; i++ {
+		if !filter.MightContain(strconv.Itoa(i)) {
+			t.Fatalf("expected error, false negative %d", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 10_000; i < 20_000; i++ {
+		if filter.MightContain(strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	if falsePositives > 200 {
+		t.Errorf("expected error, false positives=%d", falsePositives)
+	}
+
+	estimate := filter.EstimatedCardinality()
+	if estimate < 9_500 || estimate > 10_500 {
+		t.Errorf("expected error, cardinality=%d, got=%d", 10_000, estimate)
+	}
+}
+
+func TestBloomFilter_Combine(t *testing.T) {
+	a := NewBloomFilter(100, 0.01, StableStringHasher())
+	b := NewBloomFilter(100, 0.01, StableStringHasher())
+	a.AddAll(collect.NewList("x", "shared"))
+	b.AddAll(collect.NewList("y", "shared"))
+
+	union, err := a.Union(b)
+	if err != nil || !union.MightContain("x") || !union.MightContain("y") {
+		t.Errorf("expected error, incorrect union %v", err)
+	}
+	intersect, err := a.Intersect(b)
+	if err != nil || !intersect.MightContain("shared") {
+		t.Errorf("expected error, incorrect intersection %v", err)
+	}
+
+	if _, err := a.Union(NewBloomFilter(1000, 0.01, StableStringHasher())); err != ErrShapeMismatch {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
+
+func TestBloomFilter_Binary(t *testing.T) {
+	filter := NewBloomFilter(100, 0.01, StableStringHasher())
+	filter.Add("persisted")
+	data, _ := filter.MarshalBinary()
+
+	restored := NewBloomFilterWithShape(1, 1, StableStringHasher())
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !restored.MightContain("persisted") || restored.BitSize() != filter.BitSize() {
+		t.Errorf("expected error, incorrect restored filter")
+	}
+	if err := restored.UnmarshalBinary(data[:10]); err != ErrInvalidData {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
+
+func TestBloomFilter_MalformedBinary(t *testing.T) {
+	shape := func(m uint64, k uint32, payload int) []byte {
+		data := append([]byte(bloomMagic), make([]byte, 12+payload)...)
+		binary.LittleEndian.PutUint64(data[len(bloomMagic):], m)
+		binary.LittleEndian.PutUint32(data[len(bloomMagic)+8:], k)
+		return data
+	}
+
+	filter := NewBloomFilter(100, 0.01, StableStringHasher())
+	for _, data := range [][]byte{
+		shape(math.MaxUint64, 3, 0),
+		shape(math.MaxUint64-63, 3, 8),
+		shape(1<<62, 3, 0),
+		shape(65, 3, 8),
+		shape(0, 3, 8),
+		shape(64, 0, 8),
+	} {
+		if err := filter.UnmarshalBinary(data); err != ErrInvalidData {
+			t.Errorf("expected error, got=%v", err)
+		}
+	}
+	filter.Add("still usable")
+	if !filter.MightContain("still usable") {
+		t.Errorf("expected error, filter changed by malformed input")
+	}
+}
+
+func TestCountingBloomFilter_Remove(t *testing.T) {
+	filter := NewCountingBloomFilter(100, 0.01, StableStringHasher())
+	filter.Add("a")
+	filter.Add("a")
+	filter.Add("b")
+
+	filter.Remove("a")
+	if !filter.MightContain("a") {
+		t.Errorf("expected error, removed too early")
+	}
+	filter.Remove("a")
+	if filter.MightContain("a") || !filter.MightContain("b") {
+		t.Errorf("expected error, incorrect Remove")
+	}
+	if filter.Remove("missing") {
+		t.Errorf("expected error, removed absent element")
+	}
+
+	data, _ := filter.MarshalBinary()
+	restored := NewCountingBloomFilter(1, 0.5, StableStringHasher())
+	if err := restored.UnmarshalBinary(data); err != nil || !restored.MightContain("b") {
+		t.Errorf("expected error, incorrect restored filter %v", err)
+	}
+}
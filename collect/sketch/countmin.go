@@ -0,0 +1,184 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+const countMinMagic = "CMS1"
+
+type CountMinSketch[T comparable] struct {
+	counters []uint64
+	width    uint32
+	depth    uint32
+	total    uint64
+	hasher   Hasher[T]
+	topK     int
+	heavy    map[T]uint64
+}
+
+// NewCountMinSketch overestimates each count by at most epsilon*Total with
+// probability 1-delta. When topK is positive the sketch also tracks the topK
+// heavy hitters seen so far.
+func NewCountMinSketch[T comparable](epsilon, delta float64, topK int, hasher Hasher[T]) *CountMinSketch[T] {
+	if epsilon <= 0 || epsilon >= 1 || delta <= 0 || delta >= 1 {
+		panic(fmt.Sprintf("sketch: epsilon %v and delta %v must be in (0, 1)", epsilon, delta))
+	}
+	width := uint32(math.Ceil(math.E / epsilon))
+	depth := uint32(math.Ceil(math.Log(1 / delta)))
+	return NewCountMinSketchWithShape(width, depth, topK, hasher)
+}
+
+func NewCountMinSketchWithShape[T comparable](width, depth uint32, topK int, hasher Hasher[T]) *CountMinSketch[T] {
+	if width == 0 || depth == 0 {
+		panic(fmt.Sprintf("sketch: invalid count-min shape width=%d depth=%d", width, depth))
+	}
+	return &CountMinSketch[T]{
+		counters: make([]uint64, uint64(width)*uint64(depth)),
+		width:    width,
+		depth:    depth,
+		hasher:   hasher,
+		topK:     topK,
+		heavy:    make(map[T]uint64),
+	}
+}
+
+func (s *CountMinSketch[T]) Add(element T, count uint64) {
+	h1, h2 := splitHash(s.hasher(element))
+	for row := uint32(0); row < s.depth; row++ {
+		s.counters[s.index(row, h1, h2)] += count
+	}
+	s.total += count
+	s.track(element, s.Estimate(element))
+}
+
+func (s *CountMinSketch[T]) AddAll(elements collect.Collection[T]) {
+	for el := range elements.Iterator() {
+		s.Add(el, 1)
+	}
+}
+
+func (s *CountMinSketch[T]) Estimate(element T) uint64 {
+	h1, h2 := splitHash(s.hasher(element))
+	estimate := uint64(math.MaxUint64)
+	for row := uint32(0); row < s.depth; row++ {
+		if c := s.counters[s.index(row, h1, h2)]; c < estimate {
+			estimate = c
+		}
+	}
+	return estimate
+}
+
+func (s *CountMinSketch[T]) Total() uint64 {
+	return s.total
+}
+
+func (s *CountMinSketch[T]) Merge(other *CountMinSketch[T]) error {
+	if s.width != other.width || s.depth != other.depth {
+		return ErrShapeMismatch
+	}
+	for i, c := range other.counters {
+		s.counters[i] += c
+	}
+	s.total += other.total
+
+	candidates := make([]T, 0, len(s.heavy)+len(other.heavy))
+	for el := range s.heavy {
+		candidates = append(candidates, el)
+	}
+	for el := range other.heavy {
+		candidates = append(candidates, el)
+	}
+	for _, el := range candidates {
+		delete(s.heavy, el)
+		s.track(el, s.Estimate(el))
+	}
+	return nil
+}
+
+func (s *CountMinSketch[T]) HeavyHitters() *collect.ArrayList[collect.MultisetEntry[T]] {
+	entries := make([]collect.MultisetEntry[T], 0, len(s.heavy))
+	for el, count := range s.heavy {
+		entries = append(entries, collect.MultisetEntry[T]{Element: el, Count: int(count)})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Count > entries[j].Count
+	})
+	return collect.NewList(entries...)
+}
+
+func (s *CountMinSketch[T]) Clear() {
+	for i := range s.counters {
+		s.counters[i] = 0
+	}
+	s.total = 0
+	s.heavy = make(map[T]uint64)
+}
+
+// MarshalBinary stores the counters only; heavy hitters are not persisted.
+func (s *CountMinSketch[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(countMinMagic)+16+8*len(s.counters))
+	data = append(data, countMinMagic...)
+	data = binary.LittleEndian.AppendUint32(data, s.width)
+	data = binary.LittleEndian.AppendUint32(data, s.depth)
+	data = binary.LittleEndian.AppendUint64(data, s.total)
+	for _, c := range s.counters {
+		data = binary.LittleEndian.AppendUint64(data, c)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the shape and counters of s; the hasher and the
+// heavy hitter limit of s are kept.
+func (s *CountMinSketch[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < len(countMinMagic)+16 || string(data[:len(countMinMagic)]) != countMinMagic {
+		return ErrInvalidData
+	}
+	data = data[len(countMinMagic):]
+	width := binary.LittleEndian.Uint32(data)
+	depth := binary.LittleEndian.Uint32(data[4:])
+	total := binary.LittleEndian.Uint64(data[8:])
+	data = data[16:]
+	size := uint64(width) * uint64(depth)
+	if size == 0 || size > uint64(len(data))/8 || uint64(len(data)) != 8*size {
+		return ErrInvalidData
+	}
+
+	s.width, s.depth, s.total = width, depth, total
+	s.counters = make([]uint64, size)
+	for i := range s.counters {
+		s.counters[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	s.heavy = make(map[T]uint64)
+	return nil
+}
+
+func (s *CountMinSketch[T]) index(row uint32, h1, h2 uint64) uint64 {
+	return uint64(row)*uint64(s.width) + (h1+uint64(row)*h2)%uint64(s.width)
+}
+
+func (s *CountMinSketch[T]) track(element T, estimate uint64) {
+	if s.topK <= 0 {
+		return
+	}
+	if _, ok := s.heavy[element]; ok || len(s.heavy) < s.topK {
+		s.heavy[element] = estimate
+		return
+	}
+
+	var lowest T
+	lowestCount := uint64(math.MaxUint64)
+	for el, count := range s.heavy {
+		if count < lowestCount {
+			lowest, lowestCount = el, count
+		}
+	}
+	if estimate > lowestCount {
+		delete(s.heavy, lowest)
+		s.heavy[element] = estimate
+	}
+}
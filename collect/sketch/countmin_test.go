@@ -0,0 +1,57 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	sketch := NewCountMinSketch(0.001, 0.01, 2, StableStringHasher())
+	exact := collect.NewMultiset[string]()
+	for i := 0; i < 10_000; i++ {
+		el := strconv.Itoa(i % 100)
+		if i%10 == 0 {
+			el = "hot"
+		}
+		if i%50 == 0 {
+			el = "warm"
+		}
+		sketch.Add(el, 1)
+		exact.Add(el)
+	}
+
+	for el := range exact.ElementSet().Iterator() {
+		estimate := sketch.Estimate(el)
+		if estimate < uint64(exact.Count(el)) || estimate > uint64(exact.Count(el))+uint64(0.001*float64(sketch.Total())) {
+			t.Errorf("expected error, estimate=%d, got=%d", exact.Count(el), estimate)
+		}
+	}
+
+	heavy := sketch.HeavyHitters()
+	if heavy.Size() != 2 || heavy.Get(0).Element != "hot" || heavy.Get(1).Element != "warm" {
+		t.Errorf("expected error, incorrect heavy hitters %s", heavy)
+	}
+
+	data, _ := sketch.MarshalBinary()
+	restored := NewCountMinSketchWithShape[string](1, 1, 0, StableStringHasher())
+	if err := restored.UnmarshalBinary(data); err != nil || restored.Estimate("hot") != sketch.Estimate("hot") {
+		t.Errorf("expected error, incorrect restored sketch %v", err)
+	}
+	if err := restored.Merge(sketch); err != nil || restored.Total() != 2*sketch.Total() {
+		t.Errorf("expected error, incorrect merge %v", err)
+	}
+}
+
+func TestCountMinSketch_MalformedBinary(t *testing.T) {
+	data := append([]byte(countMinMagic), make([]byte, 16)...)
+	binary.LittleEndian.PutUint32(data[len(countMinMagic):], 1<<31)
+	binary.LittleEndian.PutUint32(data[len(countMinMagic)+4:], 1<<30)
+
+	sketch := NewCountMinSketch(0.01, 0.01, 0, StableStringHasher())
+	if err := sketch.UnmarshalBinary(data); err != ErrInvalidData {
+		t.Errorf("expected error, got=%v", err)
+	}
+}
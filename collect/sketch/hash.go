@@ -0,0 +1,56 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"hash/maphash"
+)
+
+// Hasher maps an element to a 64-bit hash. Sketches restored with
+// UnmarshalBinary only answer correctly when they use the same Hasher that
+// built them, so persisted sketches need a hasher that is stable across
+// processes, such as StableStringHasher; maphash seeds are not.
+type Hasher[T any] func(element T) uint64
+
+var (
+	ErrShapeMismatch = errors.New("sketch: sketches have different shapes")
+	ErrInvalidData   = errors.New("sketch: invalid binary data")
+)
+
+func StringHasher(seed maphash.Seed) Hasher[string] {
+	return func(element string) uint64 {
+		return maphash.String(seed, element)
+	}
+}
+
+func BytesHasher(seed maphash.Seed) Hasher[[]byte] {
+	return func(element []byte) uint64 {
+		return maphash.Bytes(seed, element)
+	}
+}
+
+func FormatHasher[T any](seed maphash.Seed) Hasher[T] {
+	return func(element T) uint64 {
+		return maphash.String(seed, fmt.Sprint(element))
+	}
+}
+
+func StableStringHasher() Hasher[string] {
+	return func(element string) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(element))
+		return mix(h.Sum64())
+	}
+}
+
+// mix is the splitmix64 finalizer. It spreads weak hashes over all bits and
+// derives the second hash for double hashing.
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
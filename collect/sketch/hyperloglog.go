@@ -0,0 +1,121 @@
+package sketch
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+const hyperLogLogMagic = "HLL1"
+
+type HyperLogLog[T any] struct {
+	registers []uint8
+	p         uint8
+	hasher    Hasher[T]
+}
+
+// NewHyperLogLog uses 2^precision registers; the standard error of Count is
+// about 1.04/sqrt(2^precision).
+func NewHyperLogLog[T any](precision uint8, hasher Hasher[T]) *HyperLogLog[T] {
+	if precision < 4 || precision > 18 {
+		panic(fmt.Sprintf("sketch: precision %d out of range [4, 18]", precision))
+	}
+	return &HyperLogLog[T]{
+		registers: make([]uint8, 1<<precision),
+		p:         precision,
+		hasher:    hasher,
+	}
+}
+
+func (h *HyperLogLog[T]) Add(element T) {
+	hash := mix(h.hasher(element))
+	idx := hash >> (64 - h.p)
+	rank := uint8(bits.LeadingZeros64(hash<<h.p|1<<(h.p-1)) + 1)
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+func (h *HyperLogLog[T]) AddAll(elements collect.Collection[T]) {
+	for el := range elements.Iterator() {
+		h.Add(el)
+	}
+}
+
+func (h *HyperLogLog[T]) Count() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, register := range h.registers {
+		sum += math.Ldexp(1, -int(register))
+		if register == 0 {
+			zeros++
+		}
+	}
+
+	estimate := alpha(len(h.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+func (h *HyperLogLog[T]) Merge(other *HyperLogLog[T]) error {
+	if h.p != other.p {
+		return ErrShapeMismatch
+	}
+	for i, register := range other.registers {
+		if register > h.registers[i] {
+			h.registers[i] = register
+		}
+	}
+	return nil
+}
+
+func (h *HyperLogLog[T]) Precision() uint8 {
+	return h.p
+}
+
+func (h *HyperLogLog[T]) Clear() {
+	for i := range h.registers {
+		h.registers[i] = 0
+	}
+}
+
+func (h *HyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(hyperLogLogMagic)+1+len(h.registers))
+	data = append(data, hyperLogLogMagic...)
+	data = append(data, h.p)
+	return append(data, h.registers...), nil
+}
+
+// UnmarshalBinary replaces the precision and registers of h; the hasher of h is kept.
+func (h *HyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < len(hyperLogLogMagic)+1 || string(data[:len(hyperLogLogMagic)]) != hyperLogLogMagic {
+		return ErrInvalidData
+	}
+	p := data[len(hyperLogLogMagic)]
+	registers := data[len(hyperLogLogMagic)+1:]
+	if p < 4 || p > 18 || len(registers) != 1<<p {
+		return ErrInvalidData
+	}
+
+	h.p = p
+	h.registers = append([]uint8(nil), registers...)
+	return nil
+}
+
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
@@ -0,0 +1,48 @@
+package sketch
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func TestHyperLogLog_AccuracyAgainstHashSet(t *testing.T) {
+	var elements []string
+	for i := 0; i < 100_000; i++ {
+		elements = append(elements, strconv.Itoa(i%50_000))
+	}
+	list := collect.NewList(elements...)
+	exact := collect.NewSetOf[string](list).Size()
+
+	hll := NewHyperLogLog(14, StableStringHasher())
+	hll.AddAll(list)
+	if relative := math.Abs(float64(hll.Count())-float64(exact)) / float64(exact); relative > 0.03 {
+		t.Errorf("expected error, count=%d, got=%d", exact, hll.Count())
+	}
+}
+
+func TestHyperLogLog_MergeAndBinary(t *testing.T) {
+	a := NewHyperLogLog(12, StableStringHasher())
+	b := NewHyperLogLog(12, StableStringHasher())
+	for i := 0; i < 1_000; i++ {
+		a.Add(strconv.Itoa(i))
+		b.Add(strconv.Itoa(i + 500))
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if count := a.Count(); count < 1_400 || count > 1_600 {
+		t.Errorf("expected error, count=%d, got=%d", 1_500, count)
+	}
+	if err := a.Merge(NewHyperLogLog(10, StableStringHasher())); err != ErrShapeMismatch {
+		t.Errorf("expected error, got=%v", err)
+	}
+
+	data, _ := a.MarshalBinary()
+	restored := NewHyperLogLog(4, StableStringHasher())
+	if err := restored.UnmarshalBinary(data); err != nil || restored.Count() != a.Count() {
+		t.Errorf("expected error, incorrect restored sketch %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package collect
+
+// Snapshot is a version token taken from an ArrayList, PrimaryQueue, Stack or
+// HashSet. It shares storage with the collection until the collection is
+// next modified, so taking one does not copy anything.
+type Snapshot[T comparable] struct {
+	owner any
+	slice []T
+	set   map[any]interface{}
+}
+
+func (s *Snapshot[T]) Size() int {
+	if s.set != nil {
+		return len(s.set)
+	}
+	return len(s.slice)
+}
+
+type Versioned[T comparable] interface {
+	Snapshot() *Snapshot[T]
+	Restore(snapshot *Snapshot[T])
+}
+
+// Transaction runs do and restores collection to its state before the call if
+// do returns an error or panics.
+func Transaction[T comparable](collection Versioned[T], do func() error) (err error) {
+	snapshot := collection.Snapshot()
+	committed := false
+	defer func() {
+		if !committed {
+			collection.Restore(snapshot)
+		}
+	}()
+
+	if err = do(); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+func (c *collectionWithSlice[T]) Snapshot() *Snapshot[T] {
+	c.shared = true
+	return &Snapshot[T]{owner: c, slice: (*c.data)[:len(*c.data):len(*c.data)]}
+}
+
+// Restore puts the collection back to the state captured by snapshot, which
+// stays valid and can be restored again.
+func (c *collectionWithSlice[T]) Restore(snapshot *Snapshot[T]) {
+	if snapshot.owner != c {
+		panic("collect: Restore with a snapshot of another collection")
+	}
+	*c.data = snapshot.slice
+	c.shared = true
+	c.mods++
+}
+
+func (s *HashSet[T]) Snapshot() *Snapshot[T] {
+	s.shared = true
+	return &Snapshot[T]{owner: s, set: s.data}
+}
+
+// Restore puts the set back to the state captured by snapshot, which stays
+// valid and can be restored again.
+func (s *HashSet[T]) Restore(snapshot *Snapshot[T]) {
+	if snapshot.owner != s {
+		panic("collect: Restore with a snapshot of another collection")
+	}
+	s.data = snapshot.set
+	s.shared = true
+	s.mods++
+}
+
+func (s *HashSet[T]) own() {
+	if s.shared {
+		data := make(map[any]interface{}, len(s.data))
+		for key := range s.data {
+			data[key] = nil
+		}
+		s.data = data
+		s.shared = false
+	}
+}
@@ -0,0 +1,83 @@
+package collect
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArrayList_SnapshotRestore(t *testing.T) {
+	list := NewList(1, 2, 3)
+	snapshot := list.Snapshot()
+	list.Remove(1)
+	list.Add(4)
+	if snapshot.Size() != 3 || !list.Equal(NewList(2, 3, 4)) {
+		t.Errorf("expected error, snapshot changed with list %s", list)
+	}
+
+	list.Restore(snapshot)
+	list.RemoveIf(func(el int) bool {
+		return el > 1
+	})
+	list.Restore(snapshot)
+	if !list.Equal(NewList(1, 2, 3)) {
+		t.Errorf("expected error, list=%s", list)
+	}
+}
+
+func TestHashSet_Transaction(t *testing.T) {
+	set := NewSet("a", "b")
+	err := Transaction[string](set, func() error {
+		set.Add("c")
+		set.Remove("a")
+		return errors.New("rejected")
+	})
+	if err == nil || !set.Equal(NewSet("a", "b")) {
+		t.Errorf("expected error, not rolled back %s", set)
+	}
+
+	err = Transaction[string](set, func() error {
+		set.Clear()
+		return nil
+	})
+	if err != nil || !set.IsEmpty() {
+		t.Errorf("expected error, not committed %s", set)
+	}
+}
+
+func TestArrayList_SnapshotSliceWrite(t *testing.T) {
+	list := NewList(1, 2, 3)
+	snapshot := list.Snapshot()
+	(*list.Slice())[0] = 99
+	list.Restore(snapshot)
+	if !list.Equal(NewList(1, 2, 3)) {
+		t.Errorf("expected error, snapshot changed %s", list)
+	}
+}
+
+func TestArrayList_SnapshotAnyView(t *testing.T) {
+	list := NewList(1, 2, 3)
+	snapshot := list.Snapshot()
+	list.Any().RemoveFunc(1, func(a, b int) bool {
+		return a == b
+	})
+	if !list.Equal(NewList(2, 3)) {
+		t.Errorf("expected error, view not live %s", list)
+	}
+	list.Restore(snapshot)
+	if !list.Equal(NewList(1, 2, 3)) {
+		t.Errorf("expected error, snapshot changed %s", list)
+	}
+}
+
+func TestObservableList_SnapshotSet(t *testing.T) {
+	list := NewList(1, 2, 3)
+	snapshot := list.Snapshot()
+	ObserveList[int](list).Set(0, 99)
+	if list.Get(0) != 99 {
+		t.Errorf("expected error, list=%s", list)
+	}
+	list.Restore(snapshot)
+	if !list.Equal(NewList(1, 2, 3)) {
+		t.Errorf("expected error, snapshot changed %s", list)
+	}
+}
@@ -0,0 +1,76 @@
+package collect
+
+type Stack[T comparable] struct {
+	collectionWithSlice[T]
+}
+
+func NewStack[T comparable](elements ...T) *Stack[T] {
+	return &Stack[T]{newCollectionWithSlice(&elements)}
+}
+
+func (s *Stack[T]) Push(element T) {
+	s.Add(element)
+}
+
+func (s *Stack[T]) Pop() T {
+	result, ok := s.TryPop()
+	if !ok {
+		panic("collect: Pop on empty Stack")
+	}
+	return result
+}
+
+func (s *Stack[T]) Peek() T {
+	result, ok := s.TryPeek()
+	if !ok {
+		panic("collect: Peek on empty Stack")
+	}
+	return result
+}
+
+func (s *Stack[T]) TryPop() (T, bool) {
+	result, ok := s.TryPeek()
+	if ok {
+		s.own()
+		var zero T
+		(*s.data)[len(*s.data)-1] = zero
+		*s.data = (*s.data)[:len(*s.data)-1]
+		s.mods++
+	}
+	return result, ok
+}
+
+func (s *Stack[T]) TryPeek() (T, bool) {
+	if len(*s.data) == 0 {
+		var t T
+		return t, false
+	}
+	return (*s.data)[len(*s.data)-1], true
+}
+
+// Search returns the distance of element from the top of the stack, where the
+// top is 0, or -1 if the stack does not contain it.
+func (s *Stack[T]) Search(element T) int {
+	for i := len(*s.data) - 1; i >= 0; i-- {
+		if (*s.data)[i] == element {
+			return len(*s.data) - 1 - i
+		}
+	}
+	return -1
+}
+
+func (s *Stack[T]) Equal(stack *Stack[T]) bool {
+	if stack == nil {
+		return false
+	}
+	if len(*s.data) != stack.Size() {
+		return false
+	}
+
+	for idx, val := range *s.data {
+		if (*stack.data)[idx] != val {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package collect
+
+import "testing"
+
+func TestStack_PushPop(t *testing.T) {
+	stack := NewStack(1, 2)
+	stack.Push(3)
+	if stack.Peek() != 3 || stack.Pop() != 3 || stack.Pop() != 2 || stack.Size() != 1 {
+		t.Errorf("expected error, incorrect LIFO order %s", stack)
+	}
+	stack.Pop()
+	if _, ok := stack.TryPop(); ok {
+		t.Errorf("expected error, popped from empty stack")
+	}
+	if _, ok := stack.TryPeek(); ok {
+		t.Errorf("expected error, peeked into empty stack")
+	}
+}
+
+func TestStack_Search(t *testing.T) {
+	stack := NewStack("a", "b", "a", "c")
+	if stack.Search("c") != 0 || stack.Search("a") != 1 || stack.Search("z") != -1 {
+		t.Errorf("expected error, incorrect Search %s", stack)
+	}
+}
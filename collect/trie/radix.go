@@ -0,0 +1,251 @@
+package trie
+
+import (
+	"sort"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+type node[V any] struct {
+	prefix   string
+	children []*node[V]
+	value    V
+	leaf     bool
+}
+
+// RadixTree maps strings to values with path compression: every edge holds the
+// longest run of bytes shared by all keys below it. Children are kept sorted by
+// their first byte, so walks visit keys in lexicographic byte order.
+type RadixTree[V any] struct {
+	root *node[V]
+	size int
+}
+
+func NewRadixTree[V any]() *RadixTree[V] {
+	return &RadixTree[V]{root: &node[V]{}}
+}
+
+func (t *RadixTree[V]) Insert(key string, value V) (V, bool) {
+	n := t.root
+	search := key
+	for {
+		if len(search) == 0 {
+			old, replaced := n.value, n.leaf
+			n.value, n.leaf = value, true
+			if !replaced {
+				t.size++
+			}
+			return old, replaced
+		}
+
+		idx, child := n.child(search[0])
+		if child == nil {
+			n.insertChild(&node[V]{prefix: search, value: value, leaf: true})
+			t.size++
+			var zero V
+			return zero, false
+		}
+
+		common := commonPrefix(search, child.prefix)
+		if common == len(child.prefix) {
+			n = child
+			search = search[common:]
+			continue
+		}
+
+		mid := &node[V]{prefix: child.prefix[:common], children: []*node[V]{child}}
+		child.prefix = child.prefix[common:]
+		n.children[idx] = mid
+		search = search[common:]
+		if len(search) == 0 {
+			mid.value, mid.leaf = value, true
+		} else {
+			mid.insertChild(&node[V]{prefix: search, value: value, leaf: true})
+		}
+		t.size++
+		var zero V
+		return zero, false
+	}
+}
+
+func (t *RadixTree[V]) Get(key string) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.leaf {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+func (t *RadixTree[V]) Contains(key string) bool {
+	n := t.find(key)
+	return n != nil && n.leaf
+}
+
+func (t *RadixTree[V]) Delete(key string) (V, bool) {
+	var zero V
+	path := []*node[V]{t.root}
+	n := t.root
+	search := key
+	for len(search) > 0 {
+		_, child := n.child(search[0])
+		if child == nil || len(search) < len(child.prefix) || search[:len(child.prefix)] != child.prefix {
+			return zero, false
+		}
+		search = search[len(child.prefix):]
+		n = child
+		path = append(path, n)
+	}
+	if !n.leaf {
+		return zero, false
+	}
+
+	old := n.value
+	n.value, n.leaf = zero, false
+	t.size--
+
+	if n != t.root && len(n.children) == 0 {
+		parent := path[len(path)-2]
+		parent.removeChild(n.prefix[0])
+		n = parent
+	}
+	if n != t.root && !n.leaf && len(n.children) == 1 {
+		n.mergeChild()
+	}
+	return old, true
+}
+
+func (t *RadixTree[V]) LongestPrefix(s string) (string, V, bool) {
+	var (
+		key   string
+		value V
+		found bool
+	)
+	n := t.root
+	consumed := 0
+	for {
+		if n.leaf {
+			key, value, found = s[:consumed], n.value, true
+		}
+		if consumed == len(s) {
+			break
+		}
+		_, child := n.child(s[consumed])
+		if child == nil || len(s)-consumed < len(child.prefix) || s[consumed:consumed+len(child.prefix)] != child.prefix {
+			break
+		}
+		consumed += len(child.prefix)
+		n = child
+	}
+	return key, value, found
+}
+
+// WalkPrefix visits every key starting with prefix in sorted order until do
+// returns false.
+func (t *RadixTree[V]) WalkPrefix(prefix string, do func(key string, value V) bool) {
+	n := t.root
+	search := prefix
+	for len(search) > 0 {
+		_, child := n.child(search[0])
+		if child == nil {
+			return
+		}
+		common := commonPrefix(search, child.prefix)
+		if common == len(search) {
+			walk(child, prefix+child.prefix[common:], do)
+			return
+		}
+		if common < len(child.prefix) {
+			return
+		}
+		search = search[common:]
+		n = child
+	}
+	walk(n, prefix, do)
+}
+
+func (t *RadixTree[V]) Walk(do func(key string, value V) bool) {
+	walk(t.root, "", do)
+}
+
+func (t *RadixTree[V]) KeysWithPrefix(prefix string) *collect.ArrayList[string] {
+	var keys []string
+	t.WalkPrefix(prefix, func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return collect.NewList(keys...)
+}
+
+func (t *RadixTree[V]) Len() int {
+	return t.size
+}
+
+func (t *RadixTree[V]) Clear() {
+	t.root = &node[V]{}
+	t.size = 0
+}
+
+func (t *RadixTree[V]) find(key string) *node[V] {
+	n := t.root
+	search := key
+	for len(search) > 0 {
+		_, child := n.child(search[0])
+		if child == nil || len(search) < len(child.prefix) || search[:len(child.prefix)] != child.prefix {
+			return nil
+		}
+		search = search[len(child.prefix):]
+		n = child
+	}
+	return n
+}
+
+func walk[V any](n *node[V], key string, do func(key string, value V) bool) bool {
+	if n.leaf && !do(key, n.value) {
+		return false
+	}
+	for _, child := range n.children {
+		if !walk(child, key+child.prefix, do) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *node[V]) child(label byte) (int, *node[V]) {
+	idx := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= label
+	})
+	if idx < len(n.children) && n.children[idx].prefix[0] == label {
+		return idx, n.children[idx]
+	}
+	return idx, nil
+}
+
+func (n *node[V]) insertChild(child *node[V]) {
+	idx, _ := n.child(child.prefix[0])
+	n.children = append(n.children, nil)
+	copy(n.children[idx+1:], n.children[idx:])
+	n.children[idx] = child
+}
+
+func (n *node[V]) removeChild(label byte) {
+	if idx, child := n.child(label); child != nil {
+		n.children = append(n.children[:idx], n.children[idx+1:]...)
+	}
+}
+
+func (n *node[V]) mergeChild() {
+	child := n.children[0]
+	n.prefix += child.prefix
+	n.children = child.children
+	n.value, n.leaf = child.value, child.leaf
+}
+
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,125 @@
+package trie
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func TestRadixTree_InsertDelete(t *testing.T) {
+	tree := NewRadixTree[int]()
+	keys := []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "r", ""}
+	for i, key := range keys {
+		tree.Insert(key, i)
+	}
+	if old, replaced := tree.Insert("ruber", 100); !replaced || old != 4 {
+		t.Errorf("expected error, old=%d, got=%d", 4, old)
+	}
+	if tree.Len() != len(keys) {
+		t.Errorf("expected error, len=%d, got=%d", len(keys), tree.Len())
+	}
+
+	for i, key := range keys {
+		value, ok := tree.Get(key)
+		if key != "ruber" && (!ok || value != i) {
+			t.Errorf("expected error, value=%d, got=%d", i, value)
+		}
+	}
+	if _, ok := tree.Get("rom"); ok {
+		t.Errorf("expected error, got inner node %s", "rom")
+	}
+
+	for _, key := range keys {
+		if _, ok := tree.Delete(key); !ok {
+			t.Errorf("expected error, not deleted %s", key)
+		}
+	}
+	if tree.Len() != 0 || len(tree.root.children) != 0 {
+		t.Errorf("expected error, tree not empty")
+	}
+}
+
+func TestRadixTree_Random(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	tree := NewRadixTree[int]()
+	exact := make(map[string]int)
+	for i := 0; i < 5_000; i++ {
+		key := strconv.FormatInt(int64(random.Intn(2_000)), 3)
+		if random.Intn(3) == 0 {
+			_, ok := tree.Delete(key)
+			_, expected := exact[key]
+			if ok != expected {
+				t.Fatalf("expected error, deleted=%t, got=%t", expected, ok)
+			}
+			delete(exact, key)
+		} else {
+			tree.Insert(key, i)
+			exact[key] = i
+		}
+	}
+
+	var expected []string
+	for key := range exact {
+		expected = append(expected, key)
+	}
+	sort.Strings(expected)
+	keys := tree.KeysWithPrefix("")
+	if !keys.Equal(collect.NewList(expected...)) {
+		t.Errorf("expected error, keys not sorted or incomplete")
+	}
+}
+
+func TestRadixTree_Prefixes(t *testing.T) {
+	tree := NewRadixTree[string]()
+	tree.Insert("/api", "api")
+	tree.Insert("/api/v1/users", "users")
+	tree.Insert("/api/v1/orders", "orders")
+	tree.Insert("/static", "static")
+
+	if key, value, ok := tree.LongestPrefix("/api/v1/users/42"); !ok || key != "/api/v1/users" || value != "users" {
+		t.Errorf("expected error, longest=%s, got=%s", "/api/v1/users", key)
+	}
+	if key, _, ok := tree.LongestPrefix("/api/v2"); !ok || key != "/api" {
+		t.Errorf("expected error, longest=%s, got=%s", "/api", key)
+	}
+	if _, _, ok := tree.LongestPrefix("/other"); ok {
+		t.Errorf("expected error, found prefix of %s", "/other")
+	}
+
+	keys := tree.KeysWithPrefix("/api/v")
+	if !keys.Equal(collect.NewList("/api/v1/orders", "/api/v1/users")) {
+		t.Errorf("expected error, incorrect keys %s", keys)
+	}
+
+	visited := 0
+	tree.WalkPrefix("/", func(string, string) bool {
+		visited++
+		return visited < 2
+	})
+	if visited != 2 {
+		t.Errorf("expected error, walk not stopped, visited=%d", visited)
+	}
+}
+
+func TestTrieSet(t *testing.T) {
+	set := NewSet("car", "cart", "carbon", "dog")
+	if !set.Equal(collect.NewSet("dog", "carbon", "cart", "car")) {
+		t.Errorf("expected error, sets not equal %s", set)
+	}
+	if set.String() != "[car carbon cart dog]" {
+		t.Errorf("expected error, incorrect order %s", set)
+	}
+
+	set.RemoveIf(func(s string) bool {
+		return len(s) > 4
+	})
+	if set.Contains("carbon") || !set.Contains("cart") || set.Size() != 3 {
+		t.Errorf("expected error, incorrect RemoveIf %s", set)
+	}
+	if prefix, ok := set.LongestPrefix("cartoon"); !ok || prefix != "cart" {
+		t.Errorf("expected error, longest=%s, got=%s", "cart", prefix)
+	}
+}
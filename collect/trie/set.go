@@ -0,0 +1,184 @@
+package trie
+
+import (
+	"strings"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+type TrieSet struct {
+	tree *RadixTree[struct{}]
+	mods int
+}
+
+func NewSet(elements ...string) *TrieSet {
+	set := &TrieSet{tree: NewRadixTree[struct{}]()}
+	set.AddAllSlice(elements)
+	return set
+}
+
+func NewSetOf(elements collect.Collection[string]) *TrieSet {
+	set := &TrieSet{tree: NewRadixTree[struct{}]()}
+	set.AddAll(elements)
+	return set
+}
+
+func (s *TrieSet) KeysWithPrefix(prefix string) *collect.ArrayList[string] {
+	return s.tree.KeysWithPrefix(prefix)
+}
+
+func (s *TrieSet) LongestPrefix(element string) (string, bool) {
+	key, _, ok := s.tree.LongestPrefix(element)
+	return key, ok
+}
+
+func (s *TrieSet) Equal(elements collect.Set[string]) bool {
+	if elements == nil {
+		return false
+	}
+	if s.tree.Len() != elements.Size() {
+		return false
+	}
+
+	equal := true
+	s.tree.Walk(func(key string, _ struct{}) bool {
+		equal = elements.Contains(key)
+		return equal
+	})
+	return equal
+}
+
+func (s *TrieSet) Add(element string) {
+	if _, replaced := s.tree.Insert(element, struct{}{}); !replaced {
+		s.mods++
+	}
+}
+
+func (s *TrieSet) AddAll(elements collect.Collection[string]) {
+	for el := range elements.Iterator() {
+		s.Add(el)
+	}
+}
+
+func (s *TrieSet) AddAllSlice(elements []string) {
+	for _, el := range elements {
+		s.Add(el)
+	}
+}
+
+func (s *TrieSet) Contains(element string) bool {
+	return s.tree.Contains(element)
+}
+
+func (s *TrieSet) ContainsAll(elements collect.Collection[string]) bool {
+	for el := range elements.Iterator() {
+		if !s.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *TrieSet) ContainsAllSlice(elements []string) bool {
+	for _, el := range elements {
+		if !s.Contains(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *TrieSet) Remove(element string) bool {
+	_, ok := s.tree.Delete(element)
+	if ok {
+		s.mods++
+	}
+	return ok
+}
+
+func (s *TrieSet) RemoveAll(elements collect.Collection[string]) bool {
+	modified := false
+	for el := range elements.Iterator() {
+		if s.Remove(el) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (s *TrieSet) RemoveAllSlice(elements []string) bool {
+	modified := false
+	for _, el := range elements {
+		if s.Remove(el) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (s *TrieSet) RemoveIf(predicate func(string) bool) bool {
+	var removed []string
+	s.tree.Walk(func(key string, _ struct{}) bool {
+		if predicate(key) {
+			removed = append(removed, key)
+		}
+		return true
+	})
+	return s.RemoveAllSlice(removed)
+}
+
+func (s *TrieSet) Size() int {
+	return s.tree.Len()
+}
+
+func (s *TrieSet) IsEmpty() bool {
+	return s.tree.Len() == 0
+}
+
+func (s *TrieSet) Clear() {
+	s.tree.Clear()
+	s.mods++
+}
+
+func (s *TrieSet) Iterator() <-chan string {
+	pool := make(chan string, s.tree.Len())
+	defer close(pool)
+
+	s.ForEach(func(key string) {
+		pool <- key
+	})
+
+	return pool
+}
+
+func (s *TrieSet) ForEach(do func(string)) {
+	mods := s.mods
+	s.tree.Walk(func(key string, _ struct{}) bool {
+		do(key)
+		if s.mods != mods {
+			panic(&collect.ConcurrentModificationError{Expected: mods, Actual: s.mods})
+		}
+		return true
+	})
+}
+
+func (s *TrieSet) Iter() collect.Iterator[string] {
+	keys := make([]string, 0, s.tree.Len())
+	s.tree.Walk(func(key string, _ struct{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return collect.NewIterator(keys, func() int {
+		return s.mods
+	}, func(_ int, key string) {
+		s.Remove(key)
+	})
+}
+
+func (s *TrieSet) String() string {
+	var data []string
+	s.ForEach(func(key string) {
+		data = append(data, key)
+	})
+	return "[" + strings.Join(data, " ") + "]"
+}
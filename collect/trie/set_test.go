@@ -0,0 +1,31 @@
+package trie
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ukrainskiys/go-collections/collect"
+)
+
+func TestTrieSet_IterRemove(t *testing.T) {
+	set := NewSet("go", "gopher", "rust")
+	for it := set.Iter(); it.HasNext(); {
+		if it.Next() == "gopher" {
+			it.Remove()
+		}
+	}
+	if set.Size() != 2 || set.Contains("gopher") {
+		t.Errorf("expected error, set=%s", set)
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+		var target *collect.ConcurrentModificationError
+		if !ok || !errors.As(err, &target) {
+			t.Errorf("expected error, got=%v", err)
+		}
+	}()
+	set.ForEach(func(el string) {
+		set.Add(el + "s")
+	})
+}